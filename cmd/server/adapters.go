@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/redis"
+	"go.uber.org/zap"
+)
+
+// cachingIdentificationService writes every new identification to the Redis cache, where
+// the identification service looks up the latest identity of each customer to detect
+// duplicates, and to the presence index, where the customer stays present for the visit
+// timeout of the store's policy. Cache failures are logged and never fail the identification.
+type cachingIdentificationService struct {
+	next     services.IdentificationService
+	cache    redis.Cache
+	policies *aggregates.IdentificationPolicies
+	logger   *zap.Logger
+}
+
+func (s *cachingIdentificationService) IdentifyCustomer(ctx context.Context, deviceID entities.DeviceID, beaconData entities.BeaconData) (*services.Identification, error) {
+	result, err := s.next.IdentifyCustomer(ctx, deviceID, beaconData)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIdentity(ctx, result)
+	return result, nil
+}
+
+func (s *cachingIdentificationService) IdentifyScan(ctx context.Context, deviceID entities.DeviceID, scan entities.BeaconScan) (*services.Identification, error) {
+	result, err := s.next.IdentifyScan(ctx, deviceID, scan)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIdentity(ctx, result)
+	return result, nil
+}
+
+func (s *cachingIdentificationService) IdentifyByQR(ctx context.Context, deviceID entities.DeviceID, qr entities.QRData) (*services.Identification, error) {
+	result, err := s.next.IdentifyByQR(ctx, deviceID, qr)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIdentity(ctx, result)
+	return result, nil
+}
+
+func (s *cachingIdentificationService) cacheIdentity(ctx context.Context, result *services.Identification) {
+	if result.Outcome != services.OutcomeIdentified {
+		return
+	}
+	if err := s.cache.SetCustomerIdentity(ctx, result.Identity); err != nil {
+		s.logger.Warn("Failed to cache customer identity",
+			zap.String("customer_id", result.Identity.CustomerID),
+			zap.Error(err))
+	}
+	timeout := s.policies.For(result.Identity.StoreID).VisitTimeout
+	if err := s.cache.UpdatePresence(ctx, result.Identity, timeout); err != nil {
+		s.logger.Warn("Failed to update customer presence",
+			zap.String("customer_id", result.Identity.CustomerID),
+			zap.String("store_id", result.Identity.StoreID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/sukryu/customer-id.git/internal/config"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+)
+
+// newConfidenceEstimator builds the estimator selecting the configured strategy for each store,
+// discounting the confidence of unstable signals.
+func newConfidenceEstimator(cfg config.ConfidenceConfig) (services.ConfidenceEstimator, error) {
+	pathLoss, err := services.NewPathLossEstimator(cfg.PathLoss.Exponent, cfg.PathLoss.NearDistance, cfg.PathLoss.FarDistance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path-loss parameters: %w", err)
+	}
+	byName := func(name string) (services.ConfidenceEstimator, error) {
+		switch name {
+		case "linear":
+			return services.LinearEstimator{}, nil
+		case "path_loss":
+			return pathLoss, nil
+		default:
+			return nil, fmt.Errorf("unknown confidence estimator %q", name)
+		}
+	}
+
+	fallback, err := byName(cfg.Estimator)
+	if err != nil {
+		return nil, err
+	}
+	stores := make(map[string]services.ConfidenceEstimator, len(cfg.Stores))
+	for storeID, name := range cfg.Stores {
+		if stores[storeID], err = byName(name); err != nil {
+			return nil, fmt.Errorf("store %s: %w", storeID, err)
+		}
+	}
+	estimator, err := services.NewStoreEstimator(fallback, stores)
+	if err != nil {
+		return nil, err
+	}
+	return services.NewStabilityEstimator(estimator, cfg.Smoothing.StdDevTolerance)
+}
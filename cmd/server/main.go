@@ -1 +1,88 @@
 package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sukryu/customer-id.git/internal/config"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/logging"
+	"go.uber.org/zap"
+)
+
+const usage = `Usage: customer-id <command> [arguments]
+
+Commands:
+  serve         Start the HTTP and gRPC servers (default)
+  token issue   Sign an access token for a service client
+  qr issue      Sign QR code payloads for printing at a store location
+  migrate       Apply or revert schema migrations (up, down, status, to <version>)
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "customer-id: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches to the requested subcommand. Without arguments the server is started.
+func run(args []string) error {
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		return runServe()
+	case "token":
+		return runToken(args)
+	case "qr":
+		return runQR(args)
+	case "migrate":
+		return runMigrate(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// runServe builds the service logger and serves until SIGINT or SIGTERM is received.
+func runServe() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	logger, err := logging.NewLogger(cfg.Logging)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return serve(ctx, cfg, logger)
+}
+
+// loadConfig loads the service configuration using a bootstrap logger that writes to stderr.
+func loadConfig() (*config.Config, error) {
+	bootstrap, err := zap.NewProduction()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bootstrap logger: %w", err)
+	}
+	defer bootstrap.Sync()
+
+	cfg, err := config.Load(bootstrap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
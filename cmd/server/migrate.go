@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/infrastructure/db"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/logging"
+)
+
+const migrateUsage = `Usage: customer-id migrate up|down|status|to <version>`
+
+// runMigrate implements "customer-id migrate", applying the embedded schema migrations
+// to the configured PostgreSQL database.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", migrateUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := logging.NewLogger(cfg.Logging)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer logger.Sync()
+
+	ctx := context.Background()
+	storage, err := db.NewPostgresStorage(ctx, cfg.Postgres.ConnString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	defer storage.Close()
+
+	migrator, err := db.NewMigrator(storage, logger)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf("%s", migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+		return migrator.To(ctx, version)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
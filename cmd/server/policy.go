@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/sukryu/customer-id.git/internal/config"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// newIdentificationPolicies builds the configured default policy and the store overrides,
+// whose unset rules inherit the default.
+func newIdentificationPolicies(cfg config.PolicyConfig) (*aggregates.IdentificationPolicies, error) {
+	fallback := applyRules(aggregates.DefaultIdentificationPolicy(), cfg.RulesConfig)
+	stores := make(map[string]aggregates.IdentificationPolicy, len(cfg.Stores))
+	for storeID, rules := range cfg.Stores {
+		stores[storeID] = applyRules(fallback, rules)
+	}
+	return aggregates.NewIdentificationPolicies(fallback, stores)
+}
+
+// applyRules returns policy with the rules set in rules replaced. A rule set to zero
+// replaces the policy's rule like any other value.
+func applyRules(policy aggregates.IdentificationPolicy, rules config.RulesConfig) aggregates.IdentificationPolicy {
+	if rules.MinConfidence != nil {
+		policy.MinConfidence = float32(*rules.MinConfidence)
+	}
+	if rules.DedupeWindow != nil {
+		policy.DedupeWindow = *rules.DedupeWindow
+	}
+	if len(rules.AllowedBeaconStatuses) > 0 {
+		policy.AllowedBeaconStatuses = make([]entities.BeaconStatus, len(rules.AllowedBeaconStatuses))
+		for i, status := range rules.AllowedBeaconStatuses {
+			policy.AllowedBeaconStatuses[i] = entities.BeaconStatus(status)
+		}
+	}
+	if rules.MaxClockSkew != nil {
+		policy.MaxClockSkew = *rules.MaxClockSkew
+	}
+	if rules.MaxDetectionAge != nil {
+		policy.MaxDetectionAge = *rules.MaxDetectionAge
+	}
+	if rules.VisitTimeout != nil {
+		policy.VisitTimeout = *rules.VisitTimeout
+	}
+	return policy
+}
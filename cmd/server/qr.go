@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+const qrUsage = `Usage: customer-id qr issue --store <store> --location <location> [--type <type>] [--count <n>]`
+
+// runQR implements "customer-id qr issue", printing signed QR payloads to stdout, one per line.
+// Each payload gets a fresh nonce, so several codes can be printed for the same location.
+// Payloads are signed with qr.signing_key.
+func runQR(args []string) error {
+	if len(args) == 0 || args[0] != "issue" {
+		return fmt.Errorf("%s", qrUsage)
+	}
+
+	flags := flag.NewFlagSet("qr issue", flag.ContinueOnError)
+	store := flags.String("store", "", "store the code is printed for (e.g. store100)")
+	location := flags.String("location", "", "location within the store the code is placed at (e.g. \"Table 3\")")
+	locationType := flags.String("type", string(entities.LocationTypeTable), "type of the location (entrance, table or counter)")
+	count := flags.Int("count", 1, "number of codes to issue")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *store == "" || *location == "" {
+		return fmt.Errorf("--store and --location are required\n%s", qrUsage)
+	}
+	if *count <= 0 {
+		return fmt.Errorf("--count must be positive\n%s", qrUsage)
+	}
+	loc, err := entities.NewLocation(*location, entities.LocationType(*locationType))
+	if err != nil {
+		return fmt.Errorf("invalid location: %w\n%s", err, qrUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.QR.SigningKey == "" {
+		return fmt.Errorf("qr.signing_key is not configured")
+	}
+	codec, err := auth.NewQRCodec([]byte(cfg.QR.SigningKey))
+	if err != nil {
+		return fmt.Errorf("failed to create QR codec: %w", err)
+	}
+
+	for i := 0; i < *count; i++ {
+		nonce, err := auth.NewQRNonce()
+		if err != nil {
+			return err
+		}
+		qr, err := entities.NewQRData(*store, loc, nonce)
+		if err != nil {
+			return fmt.Errorf("invalid QR data: %w", err)
+		}
+		payload, err := codec.Encode(qr)
+		if err != nil {
+			return fmt.Errorf("failed to issue QR code: %w", err)
+		}
+		fmt.Println(payload)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/config"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/db"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/kafka"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/outbox"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/redis"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/visits"
+	grpcapi "github.com/sukryu/customer-id.git/internal/interfaces/grpc"
+	httpapi "github.com/sukryu/customer-id.git/internal/interfaces/http"
+	pb "github.com/sukryu/customer-id.git/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serve wires storage, cache, the outbox relay, the visit sweeper and the identification
+// service into the HTTP and gRPC listeners and blocks until ctx is cancelled or a listener
+// fails.
+// On return all listeners are drained and every dependency is closed.
+func serve(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
+	publicKey, err := auth.LoadPublicKey(cfg.JWT.PublicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT public key: %w", err)
+	}
+	verifier, err := auth.NewVerifier(publicKey, cfg.JWT.Issuer, cfg.JWT.Audience)
+	if err != nil {
+		return fmt.Errorf("failed to create token verifier: %w", err)
+	}
+
+	storage, err := db.NewPostgresStorage(ctx, cfg.Postgres.ConnString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	defer storage.Close()
+
+	if cfg.Postgres.AutoMigrate {
+		migrator, err := db.NewMigrator(storage, logger)
+		if err != nil {
+			return fmt.Errorf("failed to load migrations: %w", err)
+		}
+		if err = migrator.Up(ctx); err != nil {
+			return fmt.Errorf("failed to migrate database schema: %w", err)
+		}
+	}
+
+	// Make sure inserts have a partition to land in before accepting traffic
+	partitions, err := db.NewPartitionManager(storage, cfg.Postgres.Partitions, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create partition manager: %w", err)
+	}
+	if err = partitions.Maintain(ctx, time.Now()); err != nil {
+		return fmt.Errorf("failed to prepare customer_identities partitions: %w", err)
+	}
+
+	cache, err := redis.NewCache(cfg.Redis.Host, cfg.Redis.Password, cfg.Redis.DB)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	defer cache.Close()
+
+	smoother, err := cache.SignalSmoother(cfg.Confidence.Smoothing.Window, cfg.Confidence.Smoothing.MaxAge)
+	if err != nil {
+		return fmt.Errorf("failed to create signal smoother: %w", err)
+	}
+
+	publisher, err := kafka.NewPublisher(cfg.Kafka)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka publisher: %w", err)
+	}
+	defer func() {
+		if err := publisher.Close(); err != nil {
+			logger.Warn("Failed to flush kafka publisher", zap.Error(err))
+		}
+	}()
+
+	estimator, err := newConfidenceEstimator(cfg.Confidence)
+	if err != nil {
+		return fmt.Errorf("failed to create confidence estimator: %w", err)
+	}
+	policies, err := newIdentificationPolicies(cfg.Policy)
+	if err != nil {
+		return fmt.Errorf("failed to create identification policies: %w", err)
+	}
+	resolver, err := services.NewNearestBeaconResolver(cfg.Confidence.Smoothing.HysteresisMargin)
+	if err != nil {
+		return fmt.Errorf("failed to create nearest beacon resolver: %w", err)
+	}
+	identification, err := services.NewIdentificationService(
+		storage,
+		storage,
+		storage,
+		services.WithIdentificationRecorder(storage),
+		services.WithConfidenceEstimator(estimator),
+		services.WithSignalSmoother(smoother),
+		services.WithNearestBeaconResolver(resolver, smoother),
+		services.WithIdentificationPolicies(policies),
+		services.WithIdentityCache(cache),
+		services.WithVisitTracking(storage),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create identification service: %w", err)
+	}
+	identification = &cachingIdentificationService{
+		next:     identification,
+		cache:    cache,
+		policies: policies,
+		logger:   logger,
+	}
+	presence, err := services.NewPresenceService(cache, storage)
+	if err != nil {
+		return fmt.Errorf("failed to create presence service: %w", err)
+	}
+
+	relay, err := outbox.NewRelay(storage, publisher, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox relay: %w", err)
+	}
+
+	sweeper, err := visits.NewSweeper(storage, cfg.Visits.SweepInterval, cfg.Visits.BatchSize, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create visit sweeper: %w", err)
+	}
+
+	authorizer, err := auth.NewAuthorizer(storage)
+	if err != nil {
+		return fmt.Errorf("failed to create authorizer: %w", err)
+	}
+
+	var qrCodec *auth.QRCodec
+	if cfg.QR.SigningKey != "" {
+		qrCodec, err = auth.NewQRCodec([]byte(cfg.QR.SigningKey))
+		if err != nil {
+			return fmt.Errorf("failed to create QR codec: %w", err)
+		}
+	} else {
+		logger.Info("QR identification disabled; set qr.signing_key to enable it")
+	}
+
+	customerIDServer, err := grpcapi.NewServer(identification, presence, authorizer, qrCodec, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC adapter: %w", err)
+	}
+	identifyHandler, err := httpapi.NewHandler(identification, presence, authorizer, qrCodec, cfg.Server.Timeout, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP adapter: %w", err)
+	}
+
+	// gRPC server with the standard health service for load balancer probes;
+	// every other method requires a bearer token
+	grpcServer := grpc.NewServer(
+		grpc.ConnectionTimeout(cfg.Server.Timeout),
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor(healthpb.Health_Check_FullMethodName)),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor(healthpb.Health_Watch_FullMethodName)),
+	)
+	pb.RegisterCustomerIDServer(grpcServer, customerIDServer)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.CustomerID_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	// HTTP server exposing the authenticated JSON identify and presence API and liveness for orchestrators
+	apiMux := http.NewServeMux()
+	identifyHandler.Register(apiMux)
+	mux := http.NewServeMux()
+	mux.Handle("/customer-id/", verifier.HTTPMiddleware(apiMux))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.Server.Timeout,
+	}
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port %d: %w", cfg.Server.GRPCPort, err)
+	}
+	httpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.HTTPPort))
+	if err != nil {
+		grpcListener.Close()
+		return fmt.Errorf("failed to listen on HTTP port %d: %w", cfg.Server.HTTPPort, err)
+	}
+
+	// The relay runs until the listeners are drained so that it picks up the last identifications
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		relay.Run(relayCtx)
+		close(relayDone)
+	}()
+	go partitions.Run(ctx)
+	go sweeper.Run(ctx)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		logger.Info("gRPC server listening", zap.String("addr", grpcListener.Addr().String()))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			serveErr <- fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}()
+	go func() {
+		logger.Info("HTTP server listening", zap.String("addr", httpListener.Addr().String()))
+		if err := httpServer.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP server failed: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining connections",
+			zap.Duration("shutdown_timeout", cfg.Server.ShutdownTimeout))
+	case err = <-serveErr:
+		logger.Error("Listener failed, shutting down", zap.Error(err))
+	}
+
+	// Report NOT_SERVING first so load balancers stop routing new calls
+	healthServer.Shutdown()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if shutdownErr := httpServer.Shutdown(shutdownCtx); shutdownErr != nil {
+		logger.Warn("HTTP server did not shut down cleanly", zap.Error(shutdownErr))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		logger.Warn("gRPC graceful stop timed out, forcing stop")
+		grpcServer.Stop()
+	}
+
+	// Stop polling, then flush what was enqueued during draining; anything left is
+	// relayed on the next start
+	stopRelay()
+	<-relayDone
+	relay.Drain(shutdownCtx)
+
+	logger.Info("Server stopped")
+	return err
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/auth"
+)
+
+const tokenUsage = `Usage: customer-id token issue --sub <subject> [--scopes <a,b>] [--stores <a,b|*>] [--ttl <duration>]`
+
+// runToken implements "customer-id token issue", printing a signed token to stdout.
+// The token is signed with jwt.private_key and its lifetime defaults to jwt.expiration.
+func runToken(args []string) error {
+	if len(args) == 0 || args[0] != "issue" {
+		return fmt.Errorf("%s", tokenUsage)
+	}
+
+	flags := flag.NewFlagSet("token issue", flag.ContinueOnError)
+	subject := flags.String("sub", "", "token subject identifying the client (e.g. pos-gateway)")
+	scopes := flags.String("scopes", "", "comma-separated scopes granted to the client (e.g. identify)")
+	stores := flags.String("stores", "", "comma-separated store IDs the client may act on, or * for all stores")
+	ttl := flags.Duration("ttl", 0, "token lifetime (defaults to jwt.expiration)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *subject == "" {
+		return fmt.Errorf("--sub is required\n%s", tokenUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if *ttl == 0 {
+		*ttl = time.Duration(cfg.JWT.Expiration) * time.Second
+	}
+
+	privateKey, err := auth.LoadPrivateKey(cfg.JWT.PrivateKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT private key: %w", err)
+	}
+	issuer, err := auth.NewIssuer(privateKey, cfg.JWT.Issuer, cfg.JWT.Audience)
+	if err != nil {
+		return fmt.Errorf("failed to create token issuer: %w", err)
+	}
+
+	token, err := issuer.Issue(*subject, splitList(*scopes), splitList(*stores), *ttl)
+	if err != nil {
+		return fmt.Errorf("failed to issue token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+)
+
+// MaxPageSize bounds the number of records returned by a single list call.
+const MaxPageSize = 1000
+
+// TimeRange restricts a query to records detected in [From, To).
+// A zero From or To leaves that side of the range open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Page selects a window of an ordered result set.
+type Page struct {
+	Limit  int // Maximum number of records to return (1 to MaxPageSize)
+	Offset int // Number of records to skip
+}
+
+// IdentityRepository defines the interface for the customer identification history.
+// It provides methods to record identities and list them by customer or beacon.
+type IdentityRepository interface {
+	// Save appends an identity to the history.
+	// Returns an error if the operation fails.
+	Save(ctx context.Context, identity *aggregates.CustomerIdentity) error
+
+	// ListByCustomer returns the identities of a customer detected within the range,
+	// most recent first. Returns an empty slice if there are none.
+	ListByCustomer(ctx context.Context, customerID string, within TimeRange, page Page) ([]*aggregates.CustomerIdentity, error)
+
+	// ListByBeacon returns the identities detected by a beacon within the range,
+	// most recent first. Returns an empty slice if there are none.
+	ListByBeacon(ctx context.Context, beaconID string, within TimeRange, page Page) ([]*aggregates.CustomerIdentity, error)
+}
+
+// IdentityCache defines the interface for looking up the latest identification of each
+// customer, against which repeated identifications are detected.
+type IdentityCache interface {
+	// GetCustomerIdentity retrieves the latest identification of customerID.
+	// Returns nil if none is cached, or an error if the operation fails.
+	GetCustomerIdentity(ctx context.Context, customerID string) (*aggregates.CustomerIdentity, error)
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+)
+
+// IdentificationRecorder defines the interface for persisting the outcome of an identification.
+// Implementations must store all parts atomically so that state and events cannot diverge.
+type IdentificationRecorder interface {
+	// RecordIdentification saves the customer, appends the identity to the identification
+	// history, saves the visits changed by the identification and enqueues event followed
+	// by the events of each visit update in the outbox, all in a single transaction.
+	// Returns an error if the operation fails, in which case nothing is persisted.
+	RecordIdentification(ctx context.Context, customer *entities.Customer, identity *aggregates.CustomerIdentity, event events.CustomerIdentified, visits ...VisitUpdate) error
+}
+
+// VisitUpdate is a visit started, extended or ended by an identification, together with
+// the events the change emitted (none if the customer stayed where they were).
+type VisitUpdate struct {
+	Visit  *aggregates.Visit
+	Events []events.Event
+}
+
+// OutboxMessage is an event stored in the transactional outbox awaiting delivery.
+type OutboxMessage struct {
+	ID        int64     // Sequential outbox ID; messages are delivered in ID order
+	EventID   string    // Unique event identifier
+	EventType string    // Event name (e.g., "CustomerIdentified")
+	Key       string    // Partitioning key of the message (e.g., customer ID)
+	Payload   []byte    // JSON-encoded event envelope
+	CreatedAt time.Time // Time the message was enqueued (UTC)
+	Attempts  int       // Number of previous delivery attempts
+}
+
+// OutboxRepository defines the interface for draining the transactional outbox.
+type OutboxRepository interface {
+	// RelayOutbox locks up to limit pending messages, oldest first, and passes each to deliver.
+	// Delivered messages are marked sent. Relaying stops at the first failed delivery, which is
+	// recorded on the message and returned, so that later messages of the same key are not
+	// delivered ahead of it. Messages locked by a concurrent relay are skipped.
+	// Returns the number of messages marked sent.
+	RelayOutbox(ctx context.Context, limit int, deliver func(ctx context.Context, msg OutboxMessage) error) (int, error)
+}
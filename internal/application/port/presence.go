@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// Occupant is a customer present in a store, at the location of their latest identification.
+type Occupant struct {
+	CustomerID string            // Present customer
+	StoreID    string            // Store the customer is in
+	Location   entities.Location // Location of the latest identification; zero if unknown
+	Since      time.Time         // Time the customer was first identified at the location (UTC)
+	LastSeenAt time.Time         // Time of the latest identification (UTC)
+	ExpiresAt  time.Time         // Time the customer leaves the index unless identified again (UTC)
+}
+
+// PresenceIndex defines the interface for the real-time index of the customers present in
+// each store and at each of its locations. A customer is present in one store at a time,
+// from an identification until timeout has passed without another one.
+type PresenceIndex interface {
+	// UpdatePresence records that the customer of identity is at its store and location,
+	// moving the customer out of any other store or location, until timeout has passed
+	// after identity was detected. Identities detected before the customer's latest
+	// identification in the index are ignored.
+	// Returns an error if the operation fails.
+	UpdatePresence(ctx context.Context, identity *aggregates.CustomerIdentity, timeout time.Duration) error
+
+	// ListOccupants returns the customers present in storeID at now, or only those at
+	// location if it is not empty, longest present at their location first.
+	// Returns an empty slice if there are none.
+	ListOccupants(ctx context.Context, storeID, location string, now time.Time) ([]Occupant, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// SignalSmoother defines the interface for smoothing RSSI readings over time.
+// Readings are kept per device/beacon pair so that every replica sees the same window.
+type SignalSmoother interface {
+	// Smooth adds the rssi reading taken at detectedAt to the window of the deviceID/beaconID
+	// pair and returns the signal smoothed over the readings in the window.
+	// Returns an error if the operation fails.
+	Smooth(ctx context.Context, deviceID entities.DeviceID, beaconID string, rssi int32, detectedAt time.Time) (entities.Signal, error)
+}
+
+// NearestBeaconRepository defines the interface for remembering the beacon each device
+// was last placed at, which lets nearest-beacon resolution apply hysteresis across scans.
+type NearestBeaconRepository interface {
+	// FindNearestBeacon retrieves the ID of the beacon deviceID was last placed at.
+	// Returns an empty string if the placement is unknown or has expired, or an error if the operation fails.
+	FindNearestBeacon(ctx context.Context, deviceID entities.DeviceID) (string, error)
+
+	// SaveNearestBeacon records that deviceID was placed at beaconID.
+	// Returns an error if the operation fails.
+	SaveNearestBeacon(ctx context.Context, deviceID entities.DeviceID, beaconID string) error
+}
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
 )
 
@@ -25,3 +26,33 @@ type BeaconRepository interface {
 	// Returns nil if not found, or an error if the operation fails.
 	FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error)
 }
+
+// StoreRepository defines the interface for store data operations.
+// It provides methods to find and save stores and to list the beacons installed in them.
+type StoreRepository interface {
+	// FindStore retrieves a store by its unique identifier.
+	// Returns nil if not found, or an error if the operation fails.
+	FindStore(ctx context.Context, storeID string) (*aggregates.Store, error)
+
+	// SaveStore persists a store, including its opening hours and floor layout.
+	// Returns an error if the operation fails.
+	SaveStore(ctx context.Context, store *aggregates.Store) error
+
+	// ListBeacons returns the beacons installed in storeID, ordered by beacon ID.
+	// Returns an empty slice if there are none, or an error if the operation fails.
+	ListBeacons(ctx context.Context, storeID string) ([]*entities.Beacon, error)
+}
+
+// CustomerDeviceRepository defines the interface for device binding operations.
+// Every app installation is bound to exactly one customer, which is how detections
+// reported by the same device are attributed to the same customer.
+type CustomerDeviceRepository interface {
+	// FindCustomerID retrieves the ID of the customer deviceID is bound to.
+	// Returns an empty string if the device is not bound, or an error if the operation fails.
+	FindCustomerID(ctx context.Context, deviceID entities.DeviceID) (string, error)
+
+	// BindDevice saves customer and binds deviceID to it atomically.
+	// If deviceID was bound concurrently, customer is not saved and the ID of the
+	// existing customer is returned instead; otherwise customer.CustomerID is returned.
+	BindDevice(ctx context.Context, deviceID entities.DeviceID, customer *entities.Customer) (string, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+)
+
+// VisitRepository defines the interface for customers' visits to stores.
+// Visits are saved together with identifications through IdentificationRecorder.
+type VisitRepository interface {
+	// FindOpenVisit retrieves the visit customerID is on, which has not ended yet.
+	// Returns nil if there is none, or an error if the operation fails.
+	FindOpenVisit(ctx context.Context, customerID string) (*aggregates.Visit, error)
+
+	// EndExpiredVisits locks up to limit open visits expired at now, oldest first, and
+	// passes each to end, which ends it and returns the event to publish. The visits are
+	// saved and their events enqueued in the outbox in a single transaction. Visits locked
+	// by a concurrent call are skipped.
+	// Returns the number of visits ended, or an error if the operation fails.
+	EndExpiredVisits(ctx context.Context, now time.Time, limit int, end func(visit *aggregates.Visit) (events.Event, error)) (int, error)
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authorizer enforces the scope and store restrictions carried in token claims.
+// Denials are returned as PERMISSION_DENIED status errors so that every transport
+// reports them consistently.
+type Authorizer struct {
+	beacons ports.BeaconRepository // Repository used to resolve the store owning a beacon
+}
+
+// NewAuthorizer creates a new Authorizer resolving beacon ownership through beacons.
+// Returns an error if dependencies are invalid.
+func NewAuthorizer(beacons ports.BeaconRepository) (*Authorizer, error) {
+	if beacons == nil {
+		return nil, fmt.Errorf("beacon repository is required")
+	}
+	return &Authorizer{
+		beacons: beacons,
+	}, nil
+}
+
+// AuthorizeStore checks that the caller in ctx holds scope and may act on storeID.
+func (a *Authorizer) AuthorizeStore(ctx context.Context, scope, storeID string) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "request is not authenticated")
+	}
+	if !claims.HasScope(scope) {
+		return status.Errorf(codes.PermissionDenied, "client %s lacks scope %q", claims.Subject, scope)
+	}
+	if !claims.AllowsStore(storeID) {
+		return status.Errorf(codes.PermissionDenied, "client %s is not allowed to access store %s", claims.Subject, storeID)
+	}
+	return nil
+}
+
+// AuthorizeIdentify checks that the caller in ctx may identify customers against the
+// beacon with the given UUID, i.e. holds the identify scope for the beacon's store.
+// Unknown beacons are let through so that identification reports them as not found.
+// Returns an ErrStorage error if the beacon cannot be resolved.
+func (a *Authorizer) AuthorizeIdentify(ctx context.Context, beaconUUID string) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "request is not authenticated")
+	}
+	if !claims.HasScope(ScopeIdentify) {
+		return status.Errorf(codes.PermissionDenied, "client %s lacks scope %q", claims.Subject, ScopeIdentify)
+	}
+
+	beacon, err := a.beacons.FindByUUID(ctx, beaconUUID)
+	if err != nil {
+		return domainerr.Errorf(domainerr.ErrStorage, "failed to resolve store for beacon %s: %w", beaconUUID, err)
+	}
+	if beacon == nil {
+		return nil
+	}
+	return a.AuthorizeStore(ctx, ScopeIdentify, beacon.StoreID)
+}
+
+// AuthorizeScan checks that the caller in ctx may identify customers against every beacon
+// of scan. A single beacon of a store outside the caller's claims denies the whole scan.
+func (a *Authorizer) AuthorizeScan(ctx context.Context, scan entities.BeaconScan) error {
+	for _, reading := range scan.Readings() {
+		if err := a.AuthorizeIdentify(ctx, reading.UUID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
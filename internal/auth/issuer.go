@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer mints RS256 tokens for service clients using the service's private key.
+// Tokens carry the configured iss and aud so that they pass Verifier checks.
+type Issuer struct {
+	privateKey *rsa.PrivateKey // Key used to sign tokens
+	issuer     string          // Value of the "iss" claim
+	audience   string          // Value of the "aud" claim
+}
+
+// NewIssuer creates a new Issuer for the given private key.
+// Empty issuer or audience omits the corresponding claim.
+// Returns an error if the private key is missing.
+func NewIssuer(privateKey *rsa.PrivateKey, issuer, audience string) (*Issuer, error) {
+	if privateKey == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+	return &Issuer{
+		privateKey: privateKey,
+		issuer:     issuer,
+		audience:   audience,
+	}, nil
+}
+
+// Issue signs a token for subject granting scopes on stores, valid from now for ttl.
+// Returns the compact serialized token or an error if the input is invalid or signing fails.
+func (i *Issuer) Issue(subject string, scopes, stores []string, ttl time.Duration) (string, error) {
+	if subject == "" {
+		return "", fmt.Errorf("subject is required")
+	}
+	if ttl <= 0 {
+		return "", fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	claims := Claims{
+		Scopes: scopes,
+		Stores: stores,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   subject,
+			Issuer:    i.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if i.audience != "" {
+		claims.Audience = jwt.ClaimStrings{i.audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(i.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token for %s: %w", subject, err)
+	}
+	return token, nil
+}
+
+// newTokenID returns a random identifier for the "jti" claim.
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a bearer token is missing, malformed, or fails verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// clockSkewLeeway tolerates small clock differences between token issuers and this service
+// when validating exp and nbf.
+const clockSkewLeeway = 30 * time.Second
+
+// Scopes granted to service clients.
+const (
+	// ScopeIdentify allows identifying customers from beacon detections.
+	ScopeIdentify = "identify"
+	// ScopeAdminBeacons allows managing the beacon inventory of a store.
+	ScopeAdminBeacons = "admin:beacons"
+	// ScopeReadCustomers allows reading customer data of a store.
+	ScopeReadCustomers = "read:customers"
+)
+
+// AllStores is the store claim value granting access to every store.
+const AllStores = "*"
+
+// Claims represents the JWT claims accepted by the customer-id service.
+type Claims struct {
+	Scopes []string `json:"scopes,omitempty"` // Operations the client may perform (e.g. "identify")
+	Stores []string `json:"stores,omitempty"` // Store IDs the client may act on ("*" for all)
+	jwt.RegisteredClaims
+}
+
+// HasScope reports whether the claims grant scope.
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsStore reports whether the claims grant access to storeID.
+// Claims without any store grant access to none.
+func (c *Claims) AllowsStore(storeID string) bool {
+	for _, s := range c.Stores {
+		if s == AllStores || s == storeID {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPublicKey reads a PEM-encoded RSA public key from path.
+// Returns an error if the file cannot be read or does not contain an RSA public key.
+func LoadPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", path, err)
+	}
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// LoadPrivateKey reads a PEM-encoded RSA private key from path.
+// Returns an error if the file cannot be read or does not contain an RSA private key.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key %s: %w", path, err)
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// Verifier validates RS256 bearer tokens against the service's public key.
+// It checks the signature, exp, nbf and, when configured, iss and aud.
+type Verifier struct {
+	publicKey *rsa.PublicKey // Key used to verify token signatures
+	parser    *jwt.Parser    // Parser preconfigured with validation options
+}
+
+// NewVerifier creates a new Verifier for the given public key.
+// Empty issuer or audience disables the corresponding check.
+// Returns an error if the public key is missing.
+func NewVerifier(publicKey *rsa.PublicKey, issuer, audience string) (*Verifier, error) {
+	if publicKey == nil {
+		return nil, fmt.Errorf("public key is required")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkewLeeway),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	return &Verifier{
+		publicKey: publicKey,
+		parser:    jwt.NewParser(opts...),
+	}, nil
+}
+
+// Verify parses and validates tokenString, returning its claims.
+// Returns an error wrapping ErrInvalidToken if verification fails.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return v.publicKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is required", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func bearerToken(header string) (string, error) {
+	if header == "" {
+		return "", fmt.Errorf("%w: missing authorization header", ErrInvalidToken)
+	}
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", fmt.Errorf("%w: authorization header must use the Bearer scheme", ErrInvalidToken)
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// claimsKey is the context key under which authenticated claims are stored.
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx carrying the authenticated claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the authenticated claims stored in ctx, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// SubjectFromContext returns the authenticated subject stored in ctx,
+// or an empty string if the request was not authenticated.
+func SubjectFromContext(ctx context.Context) string {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return claims.Subject
+}
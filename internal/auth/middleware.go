@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/sukryu/customer-id.git/internal/interfaces/apierr"
+	"google.golang.org/grpc/codes"
+)
+
+// HTTPMiddleware returns middleware that authenticates requests using the
+// Authorization header. Requests without a valid bearer token are rejected with
+// 401 and the standard error envelope; authenticated claims are stored in the
+// request context.
+func (v *Verifier) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := bearerToken(r.Header.Get("Authorization"))
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+		claims, err := v.Verify(token)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+	})
+}
+
+// unauthorized writes a 401 response with a Bearer challenge.
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="customer-id"`)
+	apierr.WriteHTTP(w, codes.Unauthenticated, err.Error())
+}
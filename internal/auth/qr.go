@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// qrPrefix identifies the payload format and version of signed QR codes.
+const qrPrefix = "TSQR1"
+
+// MinQRKeyLength is the minimum length in bytes of QR signing keys.
+const MinQRKeyLength = 32
+
+// ErrInvalidQRCode is returned when a scanned payload is malformed or its signature
+// does not match, i.e. the code was not issued by this service.
+var ErrInvalidQRCode = errors.New("invalid QR code")
+
+// qrBody is the signed content of a QR payload.
+// Codes issued before locations had a type carry no "t".
+type qrBody struct {
+	StoreID      string `json:"s"`
+	Location     string `json:"l"`
+	LocationType string `json:"t,omitempty"`
+	Nonce        string `json:"n"`
+}
+
+// QRCodec encodes QRData into signed payloads for printing and verifies scanned payloads.
+// Payloads have the form TSQR1.<body>.<signature>, where body is the base64url-encoded
+// JSON content and signature the base64url-encoded HMAC-SHA256 of "TSQR1.<body>".
+type QRCodec struct {
+	key []byte // HMAC key shared by issuing and verifying instances
+}
+
+// NewQRCodec creates a new QRCodec signing with key.
+// Returns an error if the key is shorter than MinQRKeyLength bytes.
+func NewQRCodec(key []byte) (*QRCodec, error) {
+	if len(key) < MinQRKeyLength {
+		return nil, fmt.Errorf("QR signing key must be at least %d bytes, got %d", MinQRKeyLength, len(key))
+	}
+	return &QRCodec{key: key}, nil
+}
+
+// NewQRNonce generates a random nonce for a new QR code.
+func NewQRNonce() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate QR nonce: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Encode returns the signed payload for qr.
+// Returns an error if qr is invalid.
+func (c *QRCodec) Encode(qr entities.QRData) (string, error) {
+	if err := qr.Validate(); err != nil {
+		return "", fmt.Errorf("invalid QR data: %w", err)
+	}
+	content, err := json.Marshal(qrBody{
+		StoreID:      qr.StoreID(),
+		Location:     qr.Location().Name(),
+		LocationType: string(qr.Location().Type()),
+		Nonce:        qr.Nonce(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal QR data: %w", err)
+	}
+	signed := qrPrefix + "." + base64.RawURLEncoding.EncodeToString(content)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(c.sign(signed)), nil
+}
+
+// Decode verifies the signature of payload and returns the QRData it carries.
+// The location type of codes issued before locations had a type is inferred from the
+// location name (see entities.LegacyLocation).
+// Returns an error wrapping ErrInvalidQRCode if the payload is malformed, its signature
+// does not match or its content violates QRData constraints.
+func (c *QRCodec) Decode(payload string) (entities.QRData, error) {
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 || parts[0] != qrPrefix {
+		return entities.QRData{}, fmt.Errorf("%w: payload must have the form %s.<body>.<signature>", ErrInvalidQRCode, qrPrefix)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return entities.QRData{}, fmt.Errorf("%w: malformed signature", ErrInvalidQRCode)
+	}
+	if !hmac.Equal(signature, c.sign(parts[0]+"."+parts[1])) {
+		return entities.QRData{}, fmt.Errorf("%w: signature mismatch", ErrInvalidQRCode)
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return entities.QRData{}, fmt.Errorf("%w: malformed body", ErrInvalidQRCode)
+	}
+	var body qrBody
+	if err = json.Unmarshal(content, &body); err != nil {
+		return entities.QRData{}, fmt.Errorf("%w: malformed body: %v", ErrInvalidQRCode, err)
+	}
+	location := entities.LegacyLocation(body.Location)
+	if body.LocationType != "" {
+		if location, err = entities.NewLocation(body.Location, entities.LocationType(body.LocationType)); err != nil {
+			return entities.QRData{}, fmt.Errorf("%w: %v", ErrInvalidQRCode, err)
+		}
+	}
+	qr, err := entities.NewQRData(body.StoreID, location, body.Nonce)
+	if err != nil {
+		return entities.QRData{}, fmt.Errorf("%w: %v", ErrInvalidQRCode, err)
+	}
+	return qr, nil
+}
+
+// sign returns the HMAC-SHA256 of signed under the codec key.
+func (c *QRCodec) sign(signed string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,18 +14,24 @@ import (
 
 // Config holds the configuration for the customer-id service.
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	Postgres PostgresConfig `mapstructure:"postgres"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Postgres   PostgresConfig   `mapstructure:"postgres"`
+	JWT        JWTConfig        `mapstructure:"jwt"`
+	Kafka      KafkaConfig      `mapstructure:"kafka"`
+	Outbox     OutboxConfig     `mapstructure:"outbox"`
+	Visits     VisitsConfig     `mapstructure:"visits"`
+	QR         QRConfig         `mapstructure:"qr"`
+	Confidence ConfidenceConfig `mapstructure:"confidence"`
+	Policy     PolicyConfig     `mapstructure:"policy"`
+	Logging    LoggingConfig    `mapstructure:"logging"`
 }
 
 type ServerConfig struct {
-	HTTPPort int           `mapstructure:"http_port"`
-	GRPCPort int           `mapstructure:"grpc_port"`
-	Timeout  time.Duration `mapstructure:"timeout"`
+	HTTPPort        int           `mapstructure:"http_port"`
+	GRPCPort        int           `mapstructure:"grpc_port"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
 type RedisConfig struct {
@@ -35,18 +43,51 @@ type RedisConfig struct {
 }
 
 type PostgresConfig struct {
-	Host               string `mapstructure:"host"`
-	User               string `mapstructure:"user"`
-	Password           string `mapstructure:"password"`
-	Database           string `mapstructure:"database"`
-	MaxConnections     int    `mapstructure:"max_connections"`
-	MinIdleConnections int    `mapstructure:"min_idle_connections"`
+	Host               string          `mapstructure:"host"`
+	User               string          `mapstructure:"user"`
+	Password           string          `mapstructure:"password"`
+	Database           string          `mapstructure:"database"`
+	MaxConnections     int             `mapstructure:"max_connections"`
+	MinIdleConnections int             `mapstructure:"min_idle_connections"`
+	AutoMigrate        bool            `mapstructure:"auto_migrate"`
+	Partitions         PartitionConfig `mapstructure:"partitions"`
+}
+
+// PartitionConfig controls the partitions of the customer_identities table.
+type PartitionConfig struct {
+	Granularity   string        `mapstructure:"granularity"`    // daily, monthly or yearly
+	Premake       int           `mapstructure:"premake"`        // Future partitions created ahead of time
+	Retention     time.Duration `mapstructure:"retention"`      // Age after which partitions are detached; 0 keeps all
+	DropExpired   bool          `mapstructure:"drop_expired"`   // Drop detached partitions instead of keeping them
+	CheckInterval time.Duration `mapstructure:"check_interval"` // Delay between maintenance runs
+}
+
+// ConnString builds a PostgreSQL connection URL understood by pgxpool,
+// including the pool sizing parameters when they are set.
+func (c PostgresConfig) ConnString() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   c.Host,
+		Path:   c.Database,
+	}
+	query := url.Values{}
+	if c.MaxConnections > 0 {
+		query.Set("pool_max_conns", strconv.Itoa(c.MaxConnections))
+	}
+	if c.MinIdleConnections > 0 {
+		query.Set("pool_min_conns", strconv.Itoa(c.MinIdleConnections))
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
 }
 
 type JWTConfig struct {
 	PrivateKeyPath string `mapstructure:"private_key"`
 	PublicKeyPath  string `mapstructure:"public_key"`
 	Expiration     int64  `mapstructure:"expiration"`
+	Issuer         string `mapstructure:"issuer"`
+	Audience       string `mapstructure:"audience"`
 }
 
 type KafkaConfig struct {
@@ -56,6 +97,63 @@ type KafkaConfig struct {
 	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
 }
 
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+}
+
+// VisitsConfig controls the sweeper ending visits whose customers did not return.
+// The inactivity timeout itself is a rule of the identification policy (visit_timeout).
+type VisitsConfig struct {
+	SweepInterval time.Duration `mapstructure:"sweep_interval"` // Delay between sweeps of expired visits
+	BatchSize     int           `mapstructure:"batch_size"`     // Maximum visits ended per transaction
+}
+
+// QRConfig controls the QR code identification channel.
+type QRConfig struct {
+	SigningKey string `mapstructure:"signing_key"` // HMAC key of QR payloads (at least 32 bytes); empty disables the channel
+}
+
+// ConfidenceConfig selects how the confidence of beacon detections is estimated.
+type ConfidenceConfig struct {
+	Estimator string            `mapstructure:"estimator"` // Default strategy: linear or path_loss
+	PathLoss  PathLossConfig    `mapstructure:"path_loss"` // Parameters of the path_loss strategy
+	Smoothing SmoothingConfig   `mapstructure:"smoothing"` // Averaging of readings per device/beacon pair
+	Stores    map[string]string `mapstructure:"stores"`    // Strategy overrides keyed by store ID (case-insensitive)
+}
+
+// PathLossConfig holds the parameters of the log-distance path-loss model.
+type PathLossConfig struct {
+	Exponent     float64 `mapstructure:"exponent"`      // Path-loss exponent (2 in free space, 2.7-4 indoors)
+	NearDistance float64 `mapstructure:"near_distance"` // Meters up to which confidence is 1.0
+	FarDistance  float64 `mapstructure:"far_distance"`  // Meters from which confidence is 0.0
+}
+
+// SmoothingConfig controls the moving average of RSSI readings per device/beacon pair.
+type SmoothingConfig struct {
+	Window           int           `mapstructure:"window"`            // Readings averaged per pair; 1 disables smoothing
+	MaxAge           time.Duration `mapstructure:"max_age"`           // Age after which readings leave the window
+	StdDevTolerance  float64       `mapstructure:"stddev_tolerance"`  // Standard deviation in dBm above which confidence is discounted
+	HysteresisMargin float64       `mapstructure:"hysteresis_margin"` // dB by which a beacon must beat a device's current one in scans; 0 disables hysteresis
+}
+
+// PolicyConfig holds the default identification policy and its per-store overrides.
+type PolicyConfig struct {
+	RulesConfig `mapstructure:",squash"`
+	Stores      map[string]RulesConfig `mapstructure:"stores"` // Overrides keyed by store ID (case-insensitive); unset fields inherit the default
+}
+
+// RulesConfig holds the rules of an identification policy. Rules are pointers so that
+// an explicit zero can be told apart from an unset rule, which is nil.
+type RulesConfig struct {
+	MinConfidence         *float64       `mapstructure:"min_confidence"`          // Minimum confidence of beacon identifications (0.0-1.0)
+	DedupeWindow          *time.Duration `mapstructure:"dedupe_window"`           // Minimum time between identifications of a customer
+	AllowedBeaconStatuses []string       `mapstructure:"allowed_beacon_statuses"` // Beacon statuses that may identify customers
+	MaxClockSkew          *time.Duration `mapstructure:"max_clock_skew"`          // Maximum time detections may lie in the future
+	MaxDetectionAge       *time.Duration `mapstructure:"max_detection_age"`       // Maximum time detections may lie in the past
+	VisitTimeout          *time.Duration `mapstructure:"visit_timeout"`           // Time without identifications after which a visit ends
+}
+
 type LoggingConfig struct {
 	Level    string `mapstructure:"level"`
 	Output   string `mapstructure:"output"`
@@ -81,6 +179,9 @@ func Load(logger *zap.Logger) (*Config, error) {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 
+	// Defaults of settings for which zero is a meaningful value
+	v.SetDefault("confidence.smoothing.hysteresis_margin", 6.0)
+
 	// Determine environment (default to "dev")
 	env := v.GetString("ENV")
 	if env == "" {
@@ -95,11 +196,20 @@ func Load(logger *zap.Logger) (*Config, error) {
 		return nil, fmt.Errorf("failed to resolve config path: %w", err)
 	}
 	v.AddConfigPath(configPath)
-	v.SetConfigName(fmt.Sprintf("config-%s", env))
 	v.SetConfigType("yaml")
 
-	// Attempt to read config file, falling back to defaults if not found
+	// Read the shared config.yaml first so environment files only need to carry overrides
+	v.SetConfigName("config")
 	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			logger.Error("Failed to read base config file", zap.Error(err))
+			return nil, fmt.Errorf("failed to read base config file: %w", err)
+		}
+	}
+
+	// Merge the environment-specific config file, falling back to defaults if not found
+	v.SetConfigName(fmt.Sprintf("config-%s", env))
+	if err := v.MergeInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			logger.Warn("Config file not found, using defaults and environment variables", zap.String("env", env))
 		} else {
@@ -141,6 +251,9 @@ func validateConfig(cfg *Config, logger *zap.Logger) error {
 		logger.Warn("Invalid timeout, setting default", zap.Duration("timeout", cfg.Server.Timeout))
 		cfg.Server.Timeout = 5 * time.Second
 	}
+	if cfg.Server.ShutdownTimeout <= 0 {
+		cfg.Server.ShutdownTimeout = 15 * time.Second
+	}
 	if cfg.Redis.Host == "" {
 		logger.Error("Redis host is required")
 		return fmt.Errorf("redis.host is required")
@@ -152,19 +265,157 @@ func validateConfig(cfg *Config, logger *zap.Logger) error {
 			zap.String("database", cfg.Postgres.Database))
 		return fmt.Errorf("postgres.host, user, and database are required")
 	}
+	switch cfg.Postgres.Partitions.Granularity {
+	case "":
+		cfg.Postgres.Partitions.Granularity = "monthly"
+	case "daily", "monthly", "yearly":
+	default:
+		logger.Error("Invalid partition granularity", zap.String("granularity", cfg.Postgres.Partitions.Granularity))
+		return fmt.Errorf("postgres.partitions.granularity must be daily, monthly or yearly")
+	}
+	if cfg.Postgres.Partitions.Premake <= 0 {
+		cfg.Postgres.Partitions.Premake = 3
+	}
+	if cfg.Postgres.Partitions.Retention < 0 {
+		logger.Error("Invalid partition retention", zap.Duration("retention", cfg.Postgres.Partitions.Retention))
+		return fmt.Errorf("postgres.partitions.retention must not be negative")
+	}
+	if cfg.Postgres.Partitions.CheckInterval <= 0 {
+		cfg.Postgres.Partitions.CheckInterval = time.Hour
+	}
 	if cfg.JWT.PrivateKeyPath == "" || cfg.JWT.PublicKeyPath == "" {
 		logger.Error("JWT key paths are required")
 		return fmt.Errorf("jwt.private_key and public_key are required")
 	}
+	if cfg.JWT.Expiration <= 0 {
+		logger.Warn("Invalid JWT expiration, setting default", zap.Int64("expiration", cfg.JWT.Expiration))
+		cfg.JWT.Expiration = 3600
+	}
 	if cfg.Kafka.Broker == "" || cfg.Kafka.Topic == "" {
 		logger.Error("Kafka configuration incomplete",
 			zap.String("broker", cfg.Kafka.Broker),
 			zap.String("topic", cfg.Kafka.Topic))
 		return fmt.Errorf("kafka.broker and topic are required")
 	}
+	if cfg.Outbox.PollInterval <= 0 {
+		cfg.Outbox.PollInterval = time.Second
+	}
+	if cfg.Outbox.BatchSize <= 0 {
+		cfg.Outbox.BatchSize = 100
+	}
+	if cfg.Visits.SweepInterval <= 0 {
+		cfg.Visits.SweepInterval = time.Minute
+	}
+	if cfg.Visits.BatchSize <= 0 {
+		cfg.Visits.BatchSize = 100
+	}
+	if cfg.QR.SigningKey != "" && len(cfg.QR.SigningKey) < 32 {
+		logger.Error("QR signing key too short", zap.Int("length", len(cfg.QR.SigningKey)))
+		return fmt.Errorf("qr.signing_key must be at least 32 bytes")
+	}
+	if cfg.Confidence.Estimator == "" {
+		cfg.Confidence.Estimator = "path_loss"
+	}
+	for _, estimator := range append([]string{cfg.Confidence.Estimator}, mapValues(cfg.Confidence.Stores)...) {
+		if estimator != "linear" && estimator != "path_loss" {
+			logger.Error("Invalid confidence estimator", zap.String("estimator", estimator))
+			return fmt.Errorf("confidence estimators must be linear or path_loss, got %q", estimator)
+		}
+	}
+	if cfg.Confidence.PathLoss.Exponent <= 0 {
+		cfg.Confidence.PathLoss.Exponent = 2.0
+	}
+	if cfg.Confidence.PathLoss.NearDistance <= 0 {
+		cfg.Confidence.PathLoss.NearDistance = 2.0
+	}
+	if cfg.Confidence.PathLoss.FarDistance <= 0 {
+		cfg.Confidence.PathLoss.FarDistance = 10.0
+	}
+	if cfg.Confidence.PathLoss.NearDistance >= cfg.Confidence.PathLoss.FarDistance {
+		logger.Error("Invalid path-loss distances",
+			zap.Float64("near_distance", cfg.Confidence.PathLoss.NearDistance),
+			zap.Float64("far_distance", cfg.Confidence.PathLoss.FarDistance))
+		return fmt.Errorf("confidence.path_loss.near_distance must be below far_distance")
+	}
+	if cfg.Confidence.Smoothing.Window <= 0 {
+		cfg.Confidence.Smoothing.Window = 5
+	}
+	if cfg.Confidence.Smoothing.MaxAge <= 0 {
+		cfg.Confidence.Smoothing.MaxAge = 10 * time.Second
+	}
+	if cfg.Confidence.Smoothing.StdDevTolerance <= 0 {
+		cfg.Confidence.Smoothing.StdDevTolerance = 6.0
+	}
+	if cfg.Policy.MinConfidence == nil {
+		cfg.Policy.MinConfidence = ptr(0.8)
+	}
+	if cfg.Policy.DedupeWindow == nil {
+		cfg.Policy.DedupeWindow = ptr(time.Minute)
+	}
+	if len(cfg.Policy.AllowedBeaconStatuses) == 0 {
+		cfg.Policy.AllowedBeaconStatuses = []string{"active"}
+	}
+	if cfg.Policy.MaxClockSkew == nil {
+		cfg.Policy.MaxClockSkew = ptr(30 * time.Second)
+	}
+	if cfg.Policy.MaxDetectionAge == nil {
+		cfg.Policy.MaxDetectionAge = ptr(5 * time.Minute)
+	}
+	if cfg.Policy.VisitTimeout == nil {
+		cfg.Policy.VisitTimeout = ptr(30 * time.Minute)
+	}
+	for storeID, rules := range cfg.Policy.Stores {
+		if err := validateRules(rules); err != nil {
+			logger.Error("Invalid store identification policy", zap.String("store_id", storeID), zap.Error(err))
+			return fmt.Errorf("policy.stores.%s: %w", storeID, err)
+		}
+	}
+	if err := validateRules(cfg.Policy.RulesConfig); err != nil {
+		logger.Error("Invalid identification policy", zap.Error(err))
+		return fmt.Errorf("policy: %w", err)
+	}
 	if cfg.Logging.Level == "" {
 		logger.Warn("Log level not specified, defaulting to 'info'")
 		cfg.Logging.Level = "info"
 	}
 	return nil
 }
+
+// validateRules checks the rules of an identification policy; unset rules are valid.
+func validateRules(rules RulesConfig) error {
+	if rules.MinConfidence != nil && (*rules.MinConfidence < 0 || *rules.MinConfidence > 1) {
+		return fmt.Errorf("min_confidence must be between 0 and 1")
+	}
+	if rules.DedupeWindow != nil && *rules.DedupeWindow < 0 {
+		return fmt.Errorf("dedupe_window must not be negative")
+	}
+	for _, status := range rules.AllowedBeaconStatuses {
+		if status != "active" && status != "inactive" && status != "maintenance" {
+			return fmt.Errorf("allowed_beacon_statuses must be active, inactive or maintenance, got %q", status)
+		}
+	}
+	if rules.MaxClockSkew != nil && *rules.MaxClockSkew < 0 {
+		return fmt.Errorf("max_clock_skew must not be negative")
+	}
+	if rules.MaxDetectionAge != nil && *rules.MaxDetectionAge < 0 {
+		return fmt.Errorf("max_detection_age must not be negative")
+	}
+	if rules.VisitTimeout != nil && *rules.VisitTimeout < 0 {
+		return fmt.Errorf("visit_timeout must not be negative")
+	}
+	return nil
+}
+
+// mapValues returns the values of m in unspecified order.
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
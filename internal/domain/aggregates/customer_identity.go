@@ -4,58 +4,105 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
 )
 
+// Source is the identification channel that produced a CustomerIdentity.
+type Source string
+
+const (
+	// SourceBeacon indicates identification from a beacon detection.
+	SourceBeacon Source = "beacon"
+	// SourceQR indicates identification from a scanned QR code.
+	SourceQR Source = "qr"
+)
+
 // CustomerIdentity represents the aggregate root for customer identification.
 type CustomerIdentity struct {
-	CustomerID string    // Unique identifier of the customer (references Customer).
-	BeaconID   string    // Unique identifier of the beacon (references Beacon).
-	Location   string    // Identified location (e.g., "Table 3").
-	Confidence float32   // Confidence score of identification (0.0 to 1.0).
-	DetectedAt time.Time // Timestamp of identification (UTC).
+	CustomerID string            // Unique identifier of the customer (references Customer).
+	BeaconID   string            // Unique identifier of the beacon (references Beacon); empty for QR identifications.
+	StoreID    string            // Store the customer was identified in.
+	Source     Source            // Identification channel; empty is treated as beacon for identities recorded before sources existed.
+	Location   entities.Location // Identified location (e.g., table "Table 3"); zero if unknown.
+	Confidence float32           // Confidence score of identification (0.0 to 1.0).
+	DetectedAt time.Time         // Timestamp of identification (UTC).
 }
 
-// NewCustomerIdentity creates a new CustomerIdentity instance.
-func NewCustomerIdentity(customer *entities.Customer, beacon *entities.Beacon, confidence float32, detectedAt time.Time) (*CustomerIdentity, error) {
-	if customer == nil {
-		return nil, fmt.Errorf("customer entity is required")
-	}
-	if err := customer.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid customer: %w", err)
+// NewCustomerIdentity creates a new CustomerIdentity instance from a beacon detection.
+// The beacon status, confidence and detection time must satisfy policy; whether the
+// identification is a duplicate is decided by the caller with policy.IsDuplicate.
+func NewCustomerIdentity(customer *entities.Customer, beacon *entities.Beacon, confidence float32, detectedAt time.Time, policy IdentificationPolicy) (*CustomerIdentity, error) {
+	if err := validateCustomer(customer); err != nil {
+		return nil, err
 	}
 	if beacon == nil {
-		return nil, fmt.Errorf("beacon entity is required")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "beacon entity is required")
 	}
 	if err := beacon.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid beacon: %w", err)
 	}
+	if !policy.AllowsBeaconStatus(beacon.Status) {
+		return nil, domainerr.Errorf(domainerr.ErrBeaconInactive, "beacon %s with status %s may not identify customers", beacon.BeaconID, beacon.Status)
+	}
 
 	if confidence < 0.0 || confidence > 1.0 {
-		return nil, fmt.Errorf("confidence must be between 0.0 and 1.0, got %f", confidence)
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "confidence must be between 0.0 and 1.0, got %f", confidence)
 	}
-	const minConfidence = 0.8
-	if confidence < minConfidence {
-		return nil, fmt.Errorf("confidence must be at least %f, got %f", minConfidence, confidence)
+	if confidence < policy.MinConfidence {
+		return nil, domainerr.Errorf(domainerr.ErrLowConfidence, "confidence must be at least %f, got %f", policy.MinConfidence, confidence)
 	}
 
-	if detectedAt.IsZero() {
-		return nil, fmt.Errorf("detectedAt must be set")
-	}
-	timeSinceLastSeen := detectedAt.Sub(customer.LastSeen)
-	if timeSinceLastSeen < time.Minute {
-		return nil, fmt.Errorf("duplicate identification within 1 minute: last seen %v, detected at %v", customer.LastSeen, detectedAt)
+	if err := policy.CheckDetectedAt(detectedAt); err != nil {
+		return nil, err
 	}
 
 	return &CustomerIdentity{
 		CustomerID: customer.CustomerID,
 		BeaconID:   beacon.BeaconID,
+		StoreID:    beacon.StoreID,
+		Source:     SourceBeacon,
 		Location:   beacon.Location,
 		Confidence: confidence,
 		DetectedAt: detectedAt,
 	}, nil
 }
 
+// NewQRCustomerIdentity creates a new CustomerIdentity instance from a scanned QR code.
+// Scanning a code placed at a location proves presence, so confidence is always 1.0;
+// the detection time must satisfy policy.
+func NewQRCustomerIdentity(customer *entities.Customer, qr entities.QRData, detectedAt time.Time, policy IdentificationPolicy) (*CustomerIdentity, error) {
+	if err := validateCustomer(customer); err != nil {
+		return nil, err
+	}
+	if err := qr.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid QR data: %w", err)
+	}
+	if err := policy.CheckDetectedAt(detectedAt); err != nil {
+		return nil, err
+	}
+
+	return &CustomerIdentity{
+		CustomerID: customer.CustomerID,
+		StoreID:    qr.StoreID(),
+		Source:     SourceQR,
+		Location:   qr.Location(),
+		Confidence: 1.0,
+		DetectedAt: detectedAt,
+	}, nil
+}
+
+// validateCustomer checks the customer an identity is created for.
+func validateCustomer(customer *entities.Customer) error {
+	if customer == nil {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customer entity is required")
+	}
+	if err := customer.Validate(); err != nil {
+		return fmt.Errorf("invalid customer: %w", err)
+	}
+	return nil
+}
+
 // (기존 접근자 및 Validate 메서드 유지, 필드명만 대문자로 변경 반영)
 // CustomerID returns the customer identifier.
 func (ci *CustomerIdentity) GetCustomerID() string {
@@ -67,8 +114,22 @@ func (ci *CustomerIdentity) GetBeaconID() string {
 	return ci.BeaconID
 }
 
-// Location returns the identified location.
-func (ci *CustomerIdentity) GetLocation() string {
+// GetStoreID returns the store identifier.
+func (ci *CustomerIdentity) GetStoreID() string {
+	return ci.StoreID
+}
+
+// GetSource returns the identification channel, treating identities without one as beacon.
+func (ci *CustomerIdentity) GetSource() Source {
+	if ci.Source == "" {
+		return SourceBeacon
+	}
+	return ci.Source
+}
+
+// GetLocation returns the identified location, whose type tells e.g. an entrance
+// detection from a table detection.
+func (ci *CustomerIdentity) GetLocation() entities.Location {
 	return ci.Location
 }
 
@@ -82,32 +143,47 @@ func (ci *CustomerIdentity) GetDetectedAt() time.Time {
 	return ci.DetectedAt
 }
 
-// Validate ensures the CustomerIdentity meets all domain constraints.
+// Validate ensures the CustomerIdentity is structurally sound.
+// Policy rules such as the minimum confidence are only enforced on creation, since they
+// may differ between stores and change over time.
 func (ci *CustomerIdentity) Validate() error {
 	if ci.CustomerID == "" {
-		return fmt.Errorf("customerID is required")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID is required")
 	}
 	if len(ci.CustomerID) > 64 {
-		return fmt.Errorf("customerID exceeds maximum length of 64 characters")
-	}
-	if ci.BeaconID == "" {
-		return fmt.Errorf("beaconID is required")
-	}
-	if len(ci.BeaconID) != 36 {
-		return fmt.Errorf("beaconID must be a valid UUID (36 characters)")
-	}
-	if len(ci.Location) > 32 {
-		return fmt.Errorf("location exceeds maximum length of 32 characters")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID exceeds maximum length of 64 characters")
+	}
+	switch ci.Source {
+	case SourceBeacon, "":
+		if ci.BeaconID == "" {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID is required")
+		}
+		if len(ci.BeaconID) != 36 {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID must be a valid UUID (36 characters)")
+		}
+	case SourceQR:
+		if ci.BeaconID != "" {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID must be empty for QR identifications")
+		}
+		if ci.StoreID == "" {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required for QR identifications")
+		}
+	default:
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid source: %s, must be one of beacon, qr", ci.Source)
+	}
+	if len(ci.StoreID) > 64 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID exceeds maximum length of 64 characters")
+	}
+	if !ci.Location.IsZero() {
+		if err := ci.Location.Validate(); err != nil {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location: %w", err)
+		}
 	}
 	if ci.Confidence < 0.0 || ci.Confidence > 1.0 {
-		return fmt.Errorf("confidence must be between 0.0 and 1.0, got %f", ci.Confidence)
-	}
-	const minConfidence = 0.8
-	if ci.Confidence < minConfidence {
-		return fmt.Errorf("confidence must be at least %f, got %f", minConfidence, ci.Confidence)
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "confidence must be between 0.0 and 1.0, got %f", ci.Confidence)
 	}
 	if ci.DetectedAt.IsZero() {
-		return fmt.Errorf("detectedAt must be set")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "detectedAt must be set")
 	}
 	return nil
 }
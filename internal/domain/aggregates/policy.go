@@ -0,0 +1,147 @@
+package aggregates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// IdentificationPolicy holds the rules an identification must satisfy.
+// Venues differ, e.g. a bar may accept weaker signals than a restaurant with tables
+// close together, so policies can be set per store (see IdentificationPolicies).
+type IdentificationPolicy struct {
+	MinConfidence         float32                 // Minimum confidence of beacon identifications (0.0 to 1.0)
+	DedupeWindow          time.Duration           // Minimum time between identifications of the same customer
+	AllowedBeaconStatuses []entities.BeaconStatus // Statuses of beacons that may identify customers
+	MaxClockSkew          time.Duration           // Maximum time detections may lie in the future
+	MaxDetectionAge       time.Duration           // Maximum time detections may lie in the past, e.g. when buffered by a gateway
+	VisitTimeout          time.Duration           // Time without identifications after which a customer's visit ends
+}
+
+// DefaultIdentificationPolicy returns the policy applied to stores without their own:
+// confidence of at least 0.8, one identification per customer and minute, active beacons
+// only, up to 30 seconds of clock skew, detections up to 5 minutes old and visits ending
+// after 30 minutes without identifications.
+func DefaultIdentificationPolicy() IdentificationPolicy {
+	return IdentificationPolicy{
+		MinConfidence:         0.8,
+		DedupeWindow:          time.Minute,
+		AllowedBeaconStatuses: []entities.BeaconStatus{entities.StatusActive},
+		MaxClockSkew:          30 * time.Second,
+		MaxDetectionAge:       5 * time.Minute,
+		VisitTimeout:          30 * time.Minute,
+	}
+}
+
+// AllowsBeaconStatus reports whether beacons in status may identify customers.
+func (p IdentificationPolicy) AllowsBeaconStatus(status entities.BeaconStatus) bool {
+	for _, allowed := range p.AllowedBeaconStatuses {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate ensures the IdentificationPolicy is consistent.
+// Returns an error if any constraint is violated.
+func (p IdentificationPolicy) Validate() error {
+	if p.MinConfidence < 0.0 || p.MinConfidence > 1.0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "minimum confidence must be between 0.0 and 1.0, got %f", p.MinConfidence)
+	}
+	if p.DedupeWindow < 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "dedupe window must not be negative, got %s", p.DedupeWindow)
+	}
+	if len(p.AllowedBeaconStatuses) == 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "at least one allowed beacon status is required")
+	}
+	for _, status := range p.AllowedBeaconStatuses {
+		switch status {
+		case entities.StatusActive, entities.StatusInactive, entities.StatusMaintenance:
+		default:
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid beacon status: %s, must be one of active, inactive, maintenance", status)
+		}
+	}
+	if p.MaxClockSkew < 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "max clock skew must not be negative, got %s", p.MaxClockSkew)
+	}
+	if p.MaxDetectionAge <= 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "max detection age must be positive, got %s", p.MaxDetectionAge)
+	}
+	// Repeated identifications within the dedupe window are not recorded, so a shorter
+	// timeout would end the visits of customers who never left
+	if p.VisitTimeout <= p.DedupeWindow {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "visit timeout %s must be longer than the dedupe window %s", p.VisitTimeout, p.DedupeWindow)
+	}
+	return nil
+}
+
+// CheckDetectedAt rejects detection times the policy does not allow: unset ones, ones too
+// far in the future, which point at a device clock running ahead, and stale ones, which
+// no longer say where the customer is.
+// Returns an error if detectedAt is not allowed.
+func (p IdentificationPolicy) CheckDetectedAt(detectedAt time.Time) error {
+	if detectedAt.IsZero() {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "detectedAt must be set")
+	}
+	offset := detectedAt.Sub(time.Now())
+	if offset > p.MaxClockSkew {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "detectedAt %v is %s in the future, more than the allowed clock skew of %s", detectedAt, offset, p.MaxClockSkew)
+	}
+	if -offset > p.MaxDetectionAge {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "detectedAt %v is %s old, more than the allowed age of %s", detectedAt, -offset, p.MaxDetectionAge)
+	}
+	return nil
+}
+
+// IsDuplicate reports whether an identification at location of storeID at detectedAt
+// repeats last, the latest identification of the same customer, because it lies within
+// the dedupe window. An identification that moves the customer to another store or known
+// location is not a duplicate, unless it was detected before last.
+// A customer who has not been identified before (nil last) is never a duplicate.
+func (p IdentificationPolicy) IsDuplicate(last *CustomerIdentity, storeID string, location entities.Location, detectedAt time.Time) bool {
+	if last == nil {
+		return false
+	}
+	elapsed := detectedAt.Sub(last.DetectedAt)
+	if elapsed < 0 {
+		return true
+	}
+	moved := storeID != last.StoreID || (!location.IsZero() && location != last.Location)
+	return !moved && elapsed < p.DedupeWindow
+}
+
+// IdentificationPolicies selects the IdentificationPolicy of each store, falling back to a
+// default one. Store IDs are matched case-insensitively.
+type IdentificationPolicies struct {
+	fallback IdentificationPolicy            // Policy of stores without their own
+	stores   map[string]IdentificationPolicy // Policies keyed by lower-cased store ID
+}
+
+// NewIdentificationPolicies creates a new IdentificationPolicies using fallback for every
+// store not in stores.
+// Returns an error if any policy is invalid.
+func NewIdentificationPolicies(fallback IdentificationPolicy, stores map[string]IdentificationPolicy) (*IdentificationPolicies, error) {
+	if err := fallback.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid default policy: %w", err)
+	}
+	p := &IdentificationPolicies{fallback: fallback, stores: make(map[string]IdentificationPolicy, len(stores))}
+	for storeID, policy := range stores {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid policy for store %s: %w", storeID, err)
+		}
+		p.stores[strings.ToLower(storeID)] = policy
+	}
+	return p, nil
+}
+
+// For returns the policy of storeID.
+func (p *IdentificationPolicies) For(storeID string) IdentificationPolicy {
+	if policy, ok := p.stores[strings.ToLower(storeID)]; ok {
+		return policy
+	}
+	return p.fallback
+}
@@ -0,0 +1,221 @@
+package aggregates
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// OpeningHours is the period a store is open on one day of the week, in the store's
+// local time. A period closing at or before it opens runs past midnight into the next
+// day, e.g. a bar open from 18:00 to 02:00.
+type OpeningHours struct {
+	Weekday time.Weekday  // Day the period starts on
+	Opens   time.Duration // Time since local midnight the store opens (0 to 24h, exclusive)
+	Closes  time.Duration // Time since local midnight the store closes (0 to 24h)
+}
+
+// Validate ensures the OpeningHours are consistent.
+// Returns an error if any constraint is violated.
+func (h OpeningHours) Validate() error {
+	if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid weekday: %d", h.Weekday)
+	}
+	if h.Opens < 0 || h.Opens >= 24*time.Hour {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "opening time must be between 00:00 and 24:00, got %s", h.Opens)
+	}
+	if h.Closes < 0 || h.Closes > 24*time.Hour {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "closing time must be between 00:00 and 24:00, got %s", h.Closes)
+	}
+	if h.Closes == h.Opens {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "closing time must differ from opening time %s", h.Opens)
+	}
+	return nil
+}
+
+// overnight reports whether the period runs past midnight.
+func (h OpeningHours) overnight() bool {
+	return h.Closes < h.Opens
+}
+
+// Zone is an area of a store's floor layout grouping the locations beacons are placed at,
+// e.g. the terrace with tables 10 to 14.
+type Zone struct {
+	Name      string   // Name of the zone, unique within the store (e.g., "Terrace")
+	Floor     int      // Floor the zone is on (0 for the ground floor)
+	Locations []string // Names of the locations in the zone (e.g., "Table 10"), matching the names of Beacon.Location
+}
+
+// Validate ensures the Zone meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (z Zone) Validate() error {
+	if z.Name == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "zone name is required")
+	}
+	if len(z.Name) > 32 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "zone name exceeds maximum length of 32 characters")
+	}
+	for _, location := range z.Locations {
+		if location == "" {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "zone %s has an empty location", z.Name)
+		}
+		if len(location) > 32 {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "location %s of zone %s exceeds maximum length of 32 characters", location, z.Name)
+		}
+	}
+	return nil
+}
+
+// Store represents the aggregate root of a venue beacons are installed in.
+// It holds the store's local timezone, opening hours and floor layout, so that
+// identifications can be related to local time and to areas of the store.
+type Store struct {
+	StoreID      string         // Unique identifier of the store (e.g., "store100").
+	Name         string         // Display name (e.g., "Gangnam Branch").
+	Timezone     *time.Location // Local timezone of the store (e.g., Asia/Seoul).
+	OpeningHours []OpeningHours // Weekly opening hours; empty if the store is open around the clock.
+	Zones        []Zone         // Floor layout; empty if the store has no zones.
+}
+
+// NewStore creates a new Store instance with the given ID, name and IANA timezone name,
+// without opening hours or zones.
+// Returns an error if any field is invalid or the timezone is unknown.
+func NewStore(storeID, name, timezone string) (*Store, error) {
+	if timezone == "" {
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "timezone is required")
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "unknown timezone %q: %w", timezone, err)
+	}
+	store := &Store{StoreID: storeID, Name: name, Timezone: loc}
+	if err := store.Validate(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// SetOpeningHours replaces the weekly opening hours of the store.
+// Returns an error if any period is invalid, in which case the hours are unchanged.
+func (s *Store) SetOpeningHours(hours []OpeningHours) error {
+	for _, h := range hours {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+	s.OpeningHours = append([]OpeningHours(nil), hours...)
+	return nil
+}
+
+// AddZone adds zone to the floor layout of the store.
+// Returns an error if the zone is invalid, its name is taken or one of its locations
+// already belongs to another zone.
+func (s *Store) AddZone(zone Zone) error {
+	if err := zone.Validate(); err != nil {
+		return err
+	}
+	for _, existing := range s.Zones {
+		if strings.EqualFold(existing.Name, zone.Name) {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "zone %s already exists in store %s", zone.Name, s.StoreID)
+		}
+	}
+	for _, location := range zone.Locations {
+		if other, ok := s.ZoneOf(location); ok {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "location %s already belongs to zone %s", location, other.Name)
+		}
+	}
+	zone.Locations = append([]string(nil), zone.Locations...)
+	s.Zones = append(s.Zones, zone)
+	return nil
+}
+
+// ZoneOf returns the zone containing location, matched case-insensitively.
+// Returns false if no zone contains it.
+func (s *Store) ZoneOf(location string) (Zone, bool) {
+	for _, zone := range s.Zones {
+		for _, l := range zone.Locations {
+			if strings.EqualFold(l, location) {
+				return zone, true
+			}
+		}
+	}
+	return Zone{}, false
+}
+
+// CheckBeacon ensures beacon may be installed in the store: it must belong to the store
+// and, if the store has a floor layout, be placed at a location of one of its zones.
+// Returns an error if it may not.
+func (s *Store) CheckBeacon(beacon *entities.Beacon) error {
+	if beacon == nil {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "beacon entity is required")
+	}
+	if beacon.StoreID != s.StoreID {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "beacon %s belongs to store %s, not %s", beacon.BeaconID, beacon.StoreID, s.StoreID)
+	}
+	if len(s.Zones) == 0 {
+		return nil
+	}
+	if _, ok := s.ZoneOf(beacon.Location.Name()); !ok {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "location %q of beacon %s is not in any zone of store %s", beacon.Location, beacon.BeaconID, s.StoreID)
+	}
+	return nil
+}
+
+// LocalTime returns t in the store's timezone.
+func (s *Store) LocalTime(t time.Time) time.Time {
+	return t.In(s.Timezone)
+}
+
+// IsOpenAt reports whether the store is open at t according to its opening hours in
+// local time. A store without opening hours is always open.
+func (s *Store) IsOpenAt(t time.Time) bool {
+	if len(s.OpeningHours) == 0 {
+		return true
+	}
+	local := s.LocalTime(t)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+	yesterday := (local.Weekday() + 6) % 7
+	for _, h := range s.OpeningHours {
+		switch {
+		case h.Weekday == local.Weekday() && sinceMidnight >= h.Opens && (h.overnight() || sinceMidnight < h.Closes):
+			return true
+		case h.Weekday == yesterday && h.overnight() && sinceMidnight < h.Closes:
+			return true
+		}
+	}
+	return false
+}
+
+// Validate ensures the Store aggregate meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (s *Store) Validate() error {
+	if s.StoreID == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
+	}
+	if len(s.StoreID) > 64 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID exceeds maximum length of 64 characters")
+	}
+	if s.Name == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "store name is required")
+	}
+	if len(s.Name) > 128 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "store name exceeds maximum length of 128 characters")
+	}
+	if s.Timezone == nil {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "timezone is required")
+	}
+	for _, h := range s.OpeningHours {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, zone := range s.Zones {
+		if err := zone.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package aggregates
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// Stay is the period a customer spent at one location during a visit, from the
+// identification that placed them there until the one that placed them elsewhere, or
+// until they were last seen if it is the current stay.
+type Stay struct {
+	Location entities.Location // Location of the stay (e.g., table "Table 5"); zero if unknown
+	Since    time.Time         // Time the customer was first identified at the location (UTC)
+	Until    time.Time         // Time the customer moved on or was last seen at the location (UTC)
+}
+
+// Duration returns the time spent at the location.
+func (s Stay) Duration() time.Duration {
+	return s.Until.Sub(s.Since)
+}
+
+// Visit represents the aggregate root of a customer's visit to a store: it opens with the
+// first identification at the store, records every change of location, and ends once the
+// customer has not been identified for the inactivity timeout, so that consumers see
+// "seated at table 5 for 40 minutes" instead of a stream of identifications.
+type Visit struct {
+	VisitID    string        // Unique identifier of the visit (UUID).
+	CustomerID string        // Visiting customer (references Customer).
+	StoreID    string        // Store visited.
+	StartedAt  time.Time     // Time of the first identification of the visit (UTC).
+	LastSeenAt time.Time     // Time of the latest identification of the visit (UTC).
+	EndedAt    time.Time     // Time the visit ended, i.e. LastSeenAt when it was ended (UTC); zero while open.
+	Timeout    time.Duration // Inactivity after which the visit ends.
+	Stays      []Stay        // Locations in the order visited; the last is the current one.
+	Version    int           // Number of times the visit was saved; used to detect concurrent updates.
+}
+
+// StartVisit opens a new Visit with the customer, store and location of identity, which
+// must be the first identification of the visit. The visit ends once the customer has not
+// been identified for timeout.
+// Returns an error if identity is missing or the visit would be invalid.
+func StartVisit(identity *CustomerIdentity, timeout time.Duration) (*Visit, error) {
+	if identity == nil {
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "identity is required")
+	}
+	detectedAt := identity.DetectedAt.UTC()
+	visit := &Visit{
+		VisitID:    uuid.NewString(),
+		CustomerID: identity.CustomerID,
+		StoreID:    identity.StoreID,
+		StartedAt:  detectedAt,
+		LastSeenAt: detectedAt,
+		Timeout:    timeout,
+		Stays:      []Stay{{Location: identity.Location, Since: detectedAt, Until: detectedAt}},
+	}
+	if err := visit.Validate(); err != nil {
+		return nil, err
+	}
+	return visit, nil
+}
+
+// IsOpen reports whether the visit has not ended.
+func (v *Visit) IsOpen() bool {
+	return v.EndedAt.IsZero()
+}
+
+// ExpiresAt returns the time the visit ends unless the customer is identified again.
+func (v *Visit) ExpiresAt() time.Time {
+	return v.LastSeenAt.Add(v.Timeout)
+}
+
+// IsExpiredAt reports whether the customer had not been identified for the inactivity
+// timeout at t, so that the visit is over.
+func (v *Visit) IsExpiredAt(t time.Time) bool {
+	return !t.Before(v.ExpiresAt())
+}
+
+// CurrentStay returns the stay at the location the customer was last identified at.
+func (v *Visit) CurrentStay() Stay {
+	return v.Stays[len(v.Stays)-1]
+}
+
+// Duration returns the time from the first to the latest identification of the visit.
+func (v *Visit) Duration() time.Duration {
+	return v.LastSeenAt.Sub(v.StartedAt)
+}
+
+// Record extends the visit with identity, a later identification of the same customer in
+// the same store, and reports whether the customer moved to another location. An
+// identification at an unknown location extends the current stay. Identifications
+// detected before the latest one, e.g. buffered by a gateway, change nothing, so that the
+// visit only moves forward.
+// Returns an error if the visit has ended or identity belongs to another customer or store.
+func (v *Visit) Record(identity *CustomerIdentity) (bool, error) {
+	if identity == nil {
+		return false, domainerr.Errorf(domainerr.ErrInvalidArgument, "identity is required")
+	}
+	if !v.IsOpen() {
+		return false, domainerr.Errorf(domainerr.ErrInvalidArgument, "visit %s has ended", v.VisitID)
+	}
+	if identity.CustomerID != v.CustomerID || identity.StoreID != v.StoreID {
+		return false, domainerr.Errorf(domainerr.ErrInvalidArgument, "identification of customer %s in store %s does not belong to visit %s of customer %s in store %s",
+			identity.CustomerID, identity.StoreID, v.VisitID, v.CustomerID, v.StoreID)
+	}
+	detectedAt := identity.DetectedAt.UTC()
+	if detectedAt.Before(v.LastSeenAt) {
+		return false, nil
+	}
+
+	v.LastSeenAt = detectedAt
+	current := &v.Stays[len(v.Stays)-1]
+	current.Until = detectedAt
+	if identity.Location.IsZero() || identity.Location == current.Location {
+		return false, nil
+	}
+	v.Stays = append(v.Stays, Stay{Location: identity.Location, Since: detectedAt, Until: detectedAt})
+	return true, nil
+}
+
+// End closes the visit at the time the customer was last seen.
+// Returns an error if the visit has already ended.
+func (v *Visit) End() error {
+	if !v.IsOpen() {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "visit %s has already ended", v.VisitID)
+	}
+	v.EndedAt = v.LastSeenAt
+	return nil
+}
+
+// Validate ensures the Visit aggregate meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (v *Visit) Validate() error {
+	if v.VisitID == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "visitID is required")
+	}
+	if v.CustomerID == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID is required")
+	}
+	if v.StoreID == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
+	}
+	if len(v.StoreID) > 64 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID exceeds maximum length of 64 characters")
+	}
+	if v.StartedAt.IsZero() {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "startedAt must be set")
+	}
+	if v.LastSeenAt.Before(v.StartedAt) {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "lastSeenAt %v must not be before startedAt %v", v.LastSeenAt, v.StartedAt)
+	}
+	if !v.IsOpen() && !v.EndedAt.Equal(v.LastSeenAt) {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "endedAt %v must equal lastSeenAt %v", v.EndedAt, v.LastSeenAt)
+	}
+	if v.Timeout <= 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "visit timeout must be positive, got %s", v.Timeout)
+	}
+	if len(v.Stays) == 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "at least one stay is required")
+	}
+	for i, stay := range v.Stays {
+		if !stay.Location.IsZero() {
+			if err := stay.Location.Validate(); err != nil {
+				return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location of stay %d: %w", i, err)
+			}
+		}
+		if stay.Until.Before(stay.Since) {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "stay %d ends at %v before it begins at %v", i, stay.Until, stay.Since)
+		}
+	}
+	if !v.Stays[0].Since.Equal(v.StartedAt) || !v.CurrentStay().Until.Equal(v.LastSeenAt) {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "stays must cover the visit from startedAt to lastSeenAt")
+	}
+	return nil
+}
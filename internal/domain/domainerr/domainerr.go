@@ -0,0 +1,59 @@
+// Package domainerr defines the kinds of failure of the domain layer.
+// Errors are marked with a kind using Errorf and matched with errors.Is, so that adapters
+// can tell, e.g., a request that can never succeed from a transient storage outage without
+// parsing messages.
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidArgument indicates input violating a domain constraint; retrying the same
+	// request cannot succeed.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrInvalidBeaconData indicates a beacon detection or scan violating a domain constraint.
+	ErrInvalidBeaconData = errors.New("invalid beacon data")
+
+	// ErrBeaconNotFound indicates a detected beacon that is not registered.
+	ErrBeaconNotFound = errors.New("beacon not found")
+
+	// ErrStoreNotFound indicates a store that is not registered.
+	ErrStoreNotFound = errors.New("store not found")
+
+	// ErrBeaconInactive indicates a beacon whose status may not identify customers.
+	ErrBeaconInactive = errors.New("beacon not active")
+
+	// ErrLowConfidence indicates a detection below the minimum confidence of the store.
+	ErrLowConfidence = errors.New("confidence below minimum")
+
+	// ErrDuplicate indicates an identification that has already been recorded.
+	ErrDuplicate = errors.New("duplicate identification")
+
+	// ErrStorage indicates a failure of a storage dependency; the request may succeed if retried.
+	ErrStorage = errors.New("storage unavailable")
+)
+
+// kindError is an error marked with a kind.
+type kindError struct {
+	kind error // One of the errors of this package
+	err  error // Error describing the failure
+}
+
+// Errorf formats an error like fmt.Errorf and marks it with kind, so that errors.Is(err, kind)
+// holds while the message remains that of the formatted error.
+func Errorf(kind error, format string, args ...any) error {
+	return &kindError{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// Error returns the message of the formatted error.
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the kind and the formatted error, which may wrap further errors.
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
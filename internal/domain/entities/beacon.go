@@ -1,7 +1,7 @@
 package entities
 
 import (
-	"fmt"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 )
 
 // BeaconStatus defines the possible states of a beacon device.
@@ -16,6 +16,10 @@ const (
 	StatusMaintenance BeaconStatus = "maintenance"
 )
 
+// DefaultTxPower is the typical RSSI in dBm measured 1 meter from a beacon.
+// It is assumed for beacons whose TxPower has not been calibrated.
+const DefaultTxPower int32 = -59
+
 // Beacon represents a physical beacon device used for customer identification.
 // It includes unique identification, store association, and operational status.
 type Beacon struct {
@@ -23,31 +27,34 @@ type Beacon struct {
 	StoreID  string       // Store identifier (e.g., "store100").
 	Major    int32        // Major group identifier (0-65535, e.g., store section).
 	Minor    int32        // Minor location identifier (0-65535, e.g., table number).
-	Location string       // Physical location description (e.g., "Table 3").
+	Location Location     // Location the beacon is installed at (e.g., table "Table 3"); zero if unknown.
 	Status   BeaconStatus // Operational status (active, inactive, maintenance).
+	TxPower  int32        // Calibrated RSSI in dBm measured 1 meter away (-100 to -1); 0 if not calibrated.
 }
 
 // NewBeacon creates a new Beacon instance with the given parameters.
 // It validates required fields and constraints, returning an error if invalid.
 // Default status is set to "active" if not specified.
-func NewBeacon(beaconID, storeID string, major, minor int32, location string, status BeaconStatus) (*Beacon, error) {
+func NewBeacon(beaconID, storeID string, major, minor int32, location Location, status BeaconStatus) (*Beacon, error) {
 	if beaconID == "" {
-		return nil, fmt.Errorf("beaconID is required")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID is required")
 	}
 	if len(beaconID) != 36 { // UUID format check (e.g., 8-4-4-4-12)
-		return nil, fmt.Errorf("beaconID must be a valid UUID (36 characters)")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID must be a valid UUID (36 characters)")
 	}
 	if storeID == "" {
-		return nil, fmt.Errorf("storeID is required")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
 	}
 	if major < 0 || major > 65535 {
-		return nil, fmt.Errorf("major must be between 0 and 65535")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "major must be between 0 and 65535")
 	}
 	if minor < 0 || minor > 65535 {
-		return nil, fmt.Errorf("minor must be between 0 and 65535")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "minor must be between 0 and 65535")
 	}
-	if len(location) > 32 {
-		return nil, fmt.Errorf("location exceeds maximum length of 32 characters")
+	if !location.IsZero() {
+		if err := location.Validate(); err != nil {
+			return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location: %w", err)
+		}
 	}
 	if status == "" {
 		status = StatusActive // Default to active
@@ -56,7 +63,7 @@ func NewBeacon(beaconID, storeID string, major, minor int32, location string, st
 	case StatusActive, StatusInactive, StatusMaintenance:
 		// Valid status
 	default:
-		return nil, fmt.Errorf("invalid status: %s, must be one of active, inactive, maintenance", status)
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid status: %s, must be one of active, inactive, maintenance", status)
 	}
 
 	return &Beacon{
@@ -77,35 +84,59 @@ func (b *Beacon) SetStatus(status BeaconStatus) error {
 		b.Status = status
 		return nil
 	default:
-		return fmt.Errorf("invalid status: %s, must be one of active, inactive, maintenance", status)
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid status: %s, must be one of active, inactive, maintenance", status)
 	}
 }
 
+// SetTxPower records the calibrated RSSI measured 1 meter from the beacon.
+// A txPower of 0 clears the calibration.
+func (b *Beacon) SetTxPower(txPower int32) error {
+	if txPower < -100 || txPower > 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "txPower must be between -100 and 0, got %d", txPower)
+	}
+	b.TxPower = txPower
+	return nil
+}
+
+// CalibratedTxPower returns the TxPower of the beacon, or DefaultTxPower if it has
+// not been calibrated.
+func (b *Beacon) CalibratedTxPower() int32 {
+	if b.TxPower == 0 {
+		return DefaultTxPower
+	}
+	return b.TxPower
+}
+
 // Validate ensures the Beacon entity meets all domain constraints.
 // Returns an error if any constraint is violated.
 func (b *Beacon) Validate() error {
 	if b.BeaconID == "" {
-		return fmt.Errorf("beaconID is required")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID is required")
 	}
 	if len(b.BeaconID) != 36 {
-		return fmt.Errorf("beaconID must be a valid UUID (36 characters)")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "beaconID must be a valid UUID (36 characters)")
 	}
 	if b.StoreID == "" {
-		return fmt.Errorf("storeID is required")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
 	}
 	if b.Major < 0 || b.Major > 65535 {
-		return fmt.Errorf("major must be between 0 and 65535")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "major must be between 0 and 65535")
 	}
 	if b.Minor < 0 || b.Minor > 65535 {
-		return fmt.Errorf("minor must be between 0 and 65535")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "minor must be between 0 and 65535")
+	}
+	if !b.Location.IsZero() {
+		if err := b.Location.Validate(); err != nil {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location: %w", err)
+		}
 	}
-	if len(b.Location) > 32 {
-		return fmt.Errorf("location exceeds maximum length of 32 characters")
+	if b.TxPower < -100 || b.TxPower > 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "txPower must be between -100 and 0, got %d", b.TxPower)
 	}
 	switch b.Status {
 	case StatusActive, StatusInactive, StatusMaintenance:
 		return nil
 	default:
-		return fmt.Errorf("invalid status: %s", b.Status)
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid status: %s", b.Status)
 	}
 }
@@ -1,7 +1,9 @@
 package entities
 
 import (
-	"fmt"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 )
 
 // BeaconData represents the raw data received from a beacon device.
@@ -12,6 +14,8 @@ type BeaconData struct {
 	major int32  // Major group identifier (0-65535, e.g., store section).
 	minor int32  // Minor location identifier (0-65535, e.g., table number).
 	rssi  int32  // Received Signal Strength Indicator (-100 to 0 dBm).
+
+	detectedAt time.Time // Time the beacon was detected (UTC); zero if the reporter did not say.
 }
 
 // NewBeaconData creates a new BeaconData instance with the provided values.
@@ -20,23 +24,23 @@ type BeaconData struct {
 func NewBeaconData(uuid string, major, minor, rssi int32) (BeaconData, error) {
 	// Validate UUID
 	if uuid == "" {
-		return BeaconData{}, fmt.Errorf("uuid is required")
+		return BeaconData{}, domainerr.Errorf(domainerr.ErrInvalidBeaconData, "uuid is required")
 	}
 	if len(uuid) != 36 { // UUID format: 8-4-4-4-12
-		return BeaconData{}, fmt.Errorf("uuid must be a valid UUID (36 characters), got %d", len(uuid))
+		return BeaconData{}, domainerr.Errorf(domainerr.ErrInvalidBeaconData, "uuid must be a valid UUID (36 characters), got %d", len(uuid))
 	}
 
 	// Validate major and minor
 	if major < 0 || major > 65535 {
-		return BeaconData{}, fmt.Errorf("major must be between 0 and 65535, got %d", major)
+		return BeaconData{}, domainerr.Errorf(domainerr.ErrInvalidBeaconData, "major must be between 0 and 65535, got %d", major)
 	}
 	if minor < 0 || minor > 65535 {
-		return BeaconData{}, fmt.Errorf("minor must be between 0 and 65535, got %d", minor)
+		return BeaconData{}, domainerr.Errorf(domainerr.ErrInvalidBeaconData, "minor must be between 0 and 65535, got %d", minor)
 	}
 
 	// Validate RSSI
 	if rssi < -100 || rssi > 0 {
-		return BeaconData{}, fmt.Errorf("rssi must be between -100 and 0 dBm, got %d", rssi)
+		return BeaconData{}, domainerr.Errorf(domainerr.ErrInvalidBeaconData, "rssi must be between -100 and 0 dBm, got %d", rssi)
 	}
 
 	return BeaconData{
@@ -71,23 +75,35 @@ func (bd BeaconData) RSSI() int32 {
 	return bd.rssi
 }
 
+// WithDetectedAt returns a copy of the BeaconData detected at detectedAt, as reported by
+// the device or a gateway that buffered the reading. A zero detectedAt means unknown.
+func (bd BeaconData) WithDetectedAt(detectedAt time.Time) BeaconData {
+	bd.detectedAt = detectedAt.UTC()
+	return bd
+}
+
+// DetectedAt returns the time the beacon was detected, or the zero time if unknown.
+func (bd BeaconData) DetectedAt() time.Time {
+	return bd.detectedAt
+}
+
 // Validate ensures the BeaconData meets all domain constraints.
 // Returns an error if any constraint is violated.
 func (bd BeaconData) Validate() error {
 	if bd.uuid == "" {
-		return fmt.Errorf("uuid is required")
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "uuid is required")
 	}
 	if len(bd.uuid) != 36 {
-		return fmt.Errorf("uuid must be a valid UUID (36 characters), got %d", len(bd.uuid))
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "uuid must be a valid UUID (36 characters), got %d", len(bd.uuid))
 	}
 	if bd.major < 0 || bd.major > 65535 {
-		return fmt.Errorf("major must be between 0 and 65535, got %d", bd.major)
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "major must be between 0 and 65535, got %d", bd.major)
 	}
 	if bd.minor < 0 || bd.minor > 65535 {
-		return fmt.Errorf("minor must be between 0 and 65535, got %d", bd.minor)
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "minor must be between 0 and 65535, got %d", bd.minor)
 	}
 	if bd.rssi < -100 || bd.rssi > 0 {
-		return fmt.Errorf("rssi must be between -100 and 0 dBm, got %d", bd.rssi)
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "rssi must be between -100 and 0 dBm, got %d", bd.rssi)
 	}
 	return nil
 }
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// MaxScanBeacons is the maximum number of beacons a single scan may report.
+const MaxScanBeacons = 32
+
+// BeaconScan represents all beacons a device detected in a single scan.
+// As a value object, it is immutable; every beacon appears at most once.
+type BeaconScan struct {
+	readings []BeaconData // Detections of distinct beacons, in the order reported.
+}
+
+// NewBeaconScan creates a new BeaconScan from the detections of one scan.
+// Returns an error if the scan is empty, too large, contains an invalid detection or
+// reports the same beacon twice.
+func NewBeaconScan(readings []BeaconData) (BeaconScan, error) {
+	scan := BeaconScan{readings: append([]BeaconData(nil), readings...)}
+	if err := scan.Validate(); err != nil {
+		return BeaconScan{}, err
+	}
+	return scan, nil
+}
+
+// Readings returns the detections of the scan.
+// The returned slice is a copy and may be modified freely.
+func (s BeaconScan) Readings() []BeaconData {
+	return append([]BeaconData(nil), s.readings...)
+}
+
+// Validate ensures the BeaconScan meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (s BeaconScan) Validate() error {
+	if len(s.readings) == 0 {
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "at least one beacon is required")
+	}
+	if len(s.readings) > MaxScanBeacons {
+		return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "scan exceeds maximum of %d beacons, got %d", MaxScanBeacons, len(s.readings))
+	}
+	seen := make(map[string]bool, len(s.readings))
+	for i, reading := range s.readings {
+		if err := reading.Validate(); err != nil {
+			return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "beacon %d: %w", i, err)
+		}
+		if seen[reading.UUID()] {
+			return domainerr.Errorf(domainerr.ErrInvalidBeaconData, "beacon %s reported more than once", reading.UUID())
+		}
+		seen[reading.UUID()] = true
+	}
+	return nil
+}
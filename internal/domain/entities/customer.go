@@ -1,8 +1,9 @@
 package entities
 
 import (
-	"fmt"
 	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 )
 
 // Customer represents an identified customer in the TasteSync system.
@@ -19,10 +20,10 @@ type Customer struct {
 // Returns an error if validation fails.
 func NewCustomer(customerID string, preferences map[string]string) (*Customer, error) {
 	if customerID == "" {
-		return nil, fmt.Errorf("customerID is required")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID is required")
 	}
 	if len(customerID) > 64 {
-		return nil, fmt.Errorf("customerID exceeds maximum length of 64 characters")
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID exceeds maximum length of 64 characters")
 	}
 	if preferences == nil {
 		preferences = make(map[string]string) // Initialize empty map to avoid nil
@@ -35,17 +36,19 @@ func NewCustomer(customerID string, preferences map[string]string) (*Customer, e
 	}, nil
 }
 
-// UpdateLastSeen updates the customer's LastSeen timestamp to the current UTC time.
-// This method ensures the customer's activity is tracked accurately.
-func (c *Customer) UpdateLastSeen() {
-	c.LastSeen = time.Now().UTC()
+// SeenAt records that the customer was seen at seenAt. LastSeen only moves forward, so a
+// late reading, e.g. one buffered by a gateway, does not roll back a newer sighting.
+func (c *Customer) SeenAt(seenAt time.Time) {
+	if seenAt = seenAt.UTC(); seenAt.After(c.LastSeen) {
+		c.LastSeen = seenAt
+	}
 }
 
 // AddPreference adds or updates a preference key-value pair for the customer.
 // It ensures that preferences remain mutable and extensible.
 func (c *Customer) AddPreference(key, value string) error {
 	if key == "" {
-		return fmt.Errorf("preference key cannot be empty")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "preference key cannot be empty")
 	}
 	if c.Preferences == nil {
 		c.Preferences = make(map[string]string)
@@ -58,13 +61,13 @@ func (c *Customer) AddPreference(key, value string) error {
 // Returns an error if any constraint is violated.
 func (c *Customer) Validate() error {
 	if c.CustomerID == "" {
-		return fmt.Errorf("customerID is required")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID is required")
 	}
 	if len(c.CustomerID) > 64 {
-		return fmt.Errorf("customerID exceeds maximum length of 64 characters")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "customerID exceeds maximum length of 64 characters")
 	}
 	if c.LastSeen.IsZero() {
-		return fmt.Errorf("lastSeen must be set")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "lastSeen must be set")
 	}
 	return nil
 }
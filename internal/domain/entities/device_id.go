@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// maxDeviceIDLength bounds device identifiers, matching customer_devices.device_id.
+const maxDeviceIDLength = 128
+
+// DeviceID identifies the app installation that reported a detection.
+// As a value object, it is immutable; the mobile app generates it once per install
+// (e.g., a random UUID) and presents it with every identification request, which is
+// what binds detections to a single customer.
+type DeviceID struct {
+	value string // Opaque identifier (1-128 printable ASCII characters, no spaces).
+}
+
+// NewDeviceID creates a new DeviceID from the identifier presented by the app.
+// Returns an error if the identifier is empty, too long or contains unsupported characters.
+func NewDeviceID(value string) (DeviceID, error) {
+	d := DeviceID{value: value}
+	if err := d.Validate(); err != nil {
+		return DeviceID{}, err
+	}
+	return d, nil
+}
+
+// String returns the device identifier.
+func (d DeviceID) String() string {
+	return d.value
+}
+
+// Validate ensures the DeviceID meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (d DeviceID) Validate() error {
+	if d.value == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "device_id is required")
+	}
+	if len(d.value) > maxDeviceIDLength {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "device_id exceeds maximum length of %d characters, got %d", maxDeviceIDLength, len(d.value))
+	}
+	for _, c := range d.value {
+		if c <= ' ' || c > '~' {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "device_id must contain printable ASCII characters only")
+		}
+	}
+	return nil
+}
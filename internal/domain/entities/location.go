@@ -1,7 +1,10 @@
 package entities
 
 import (
-	"fmt"
+	"encoding/json"
+	"strings"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 )
 
 // LocationType defines the possible types of a location within a store.
@@ -18,6 +21,7 @@ const (
 
 // Location represents a physical location within a store as a value object.
 // It is immutable and used to describe where a customer is identified.
+// The zero Location means the location is unknown.
 type Location struct {
 	name  string       // Name of the location (e.g., "Table 3").
 	type_ LocationType // Type of the location (entrance, table, counter).
@@ -29,10 +33,10 @@ type Location struct {
 func NewLocation(name string, locationType LocationType) (Location, error) {
 	// Validate name
 	if name == "" {
-		return Location{}, fmt.Errorf("name is required")
+		return Location{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "name is required")
 	}
 	if len(name) > 32 {
-		return Location{}, fmt.Errorf("name exceeds maximum length of 32 characters, got %d", len(name))
+		return Location{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "name exceeds maximum length of 32 characters, got %d", len(name))
 	}
 
 	// Validate location type
@@ -40,7 +44,7 @@ func NewLocation(name string, locationType LocationType) (Location, error) {
 	case LocationTypeEntrance, LocationTypeTable, LocationTypeCounter:
 		// Valid type
 	default:
-		return Location{}, fmt.Errorf("invalid location type: %s, must be one of entrance, table, counter", locationType)
+		return Location{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location type: %s, must be one of entrance, table, counter", locationType)
 	}
 
 	return Location{
@@ -61,19 +65,86 @@ func (l Location) Type() LocationType {
 	return l.type_
 }
 
+// LegacyLocation returns the Location named name for locations recorded before they had
+// a type: names mentioning an entrance or a counter get that type, all others are tables.
+// An empty name yields the zero Location.
+func LegacyLocation(name string) Location {
+	if name == "" {
+		return Location{}
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, string(LocationTypeEntrance)):
+		return Location{name: name, type_: LocationTypeEntrance}
+	case strings.Contains(lower, string(LocationTypeCounter)):
+		return Location{name: name, type_: LocationTypeCounter}
+	default:
+		return Location{name: name, type_: LocationTypeTable}
+	}
+}
+
+// IsZero reports whether the location is unknown.
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+// String returns the location's name.
+func (l Location) String() string {
+	return l.name
+}
+
+// locationJSON is the JSON form of a Location.
+type locationJSON struct {
+	Name string       `json:"name"`
+	Type LocationType `json:"type"`
+}
+
+// MarshalJSON encodes the location as {"name": ..., "type": ...}, or null if it is unknown.
+func (l Location) MarshalJSON() ([]byte, error) {
+	if l.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(locationJSON{Name: l.name, Type: l.type_})
+}
+
+// UnmarshalJSON decodes a location encoded by MarshalJSON. A plain string, as written
+// before locations had a type, is decoded with LegacyLocation.
+// Returns an error if the location is malformed or violates domain constraints.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*l = Location{}
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = LegacyLocation(name)
+		return nil
+	}
+	var decoded locationJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "malformed location: %w", err)
+	}
+	location, err := NewLocation(decoded.Name, decoded.Type)
+	if err != nil {
+		return err
+	}
+	*l = location
+	return nil
+}
+
 // Validate ensures the Location meets all domain constraints.
 // Returns an error if any constraint is violated.
 func (l Location) Validate() error {
 	if l.name == "" {
-		return fmt.Errorf("name is required")
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "name is required")
 	}
 	if len(l.name) > 32 {
-		return fmt.Errorf("name exceeds maximum length of 32 characters, got %d", len(l.name))
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "name exceeds maximum length of 32 characters, got %d", len(l.name))
 	}
 	switch l.type_ {
 	case LocationTypeEntrance, LocationTypeTable, LocationTypeCounter:
 		return nil
 	default:
-		return fmt.Errorf("invalid location type: %s", l.type_)
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location type: %s", l.type_)
 	}
 }
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// QRData represents the content of a QR code printed for a store location.
+// As a value object, it is immutable; it is only trusted once the signature of the
+// scanned payload has been verified (see auth.QRCodec).
+type QRData struct {
+	storeID  string   // Store identifier (e.g., "store100").
+	location Location // Location the code is placed at (e.g., table "Table 3").
+	nonce    string   // Random value distinguishing codes printed for the same location.
+
+	detectedAt time.Time // Time the code was scanned (UTC); zero if the device did not say. Not part of the signed payload.
+}
+
+// NewQRData creates a new QRData instance with the provided values.
+// It enforces the same store and location constraints as Beacon, except that the
+// location is required.
+// Returns an error if any constraint is violated.
+func NewQRData(storeID string, location Location, nonce string) (QRData, error) {
+	qr := QRData{
+		storeID:  storeID,
+		location: location,
+		nonce:    nonce,
+	}
+	if err := qr.Validate(); err != nil {
+		return QRData{}, err
+	}
+	return qr, nil
+}
+
+// StoreID returns the store the QR code was printed for.
+func (qr QRData) StoreID() string {
+	return qr.storeID
+}
+
+// Location returns the location within the store the QR code is placed at.
+func (qr QRData) Location() Location {
+	return qr.location
+}
+
+// Nonce returns the random value identifying the printed code.
+func (qr QRData) Nonce() string {
+	return qr.nonce
+}
+
+// WithDetectedAt returns a copy of the QRData scanned at detectedAt, as reported by the
+// device. A zero detectedAt means unknown.
+func (qr QRData) WithDetectedAt(detectedAt time.Time) QRData {
+	qr.detectedAt = detectedAt.UTC()
+	return qr
+}
+
+// DetectedAt returns the time the code was scanned, or the zero time if unknown.
+func (qr QRData) DetectedAt() time.Time {
+	return qr.detectedAt
+}
+
+// Validate ensures the QRData meets all domain constraints.
+// Returns an error if any constraint is violated.
+func (qr QRData) Validate() error {
+	if qr.storeID == "" {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
+	}
+	if len(qr.storeID) > 64 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID exceeds maximum length of 64 characters")
+	}
+	if qr.location.IsZero() {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "location is required")
+	}
+	if err := qr.location.Validate(); err != nil {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid location: %w", err)
+	}
+	if len(qr.nonce) < 8 || len(qr.nonce) > 64 {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "nonce must be between 8 and 64 characters, got %d", len(qr.nonce))
+	}
+	for _, c := range qr.nonce {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return domainerr.Errorf(domainerr.ErrInvalidArgument, "nonce must contain only letters, digits, '-' and '_'")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// Signal represents the RSSI of a device/beacon pair smoothed over a window of readings.
+// As a value object, it is immutable; the variance of the readings tells how stable the
+// signal was over the window.
+type Signal struct {
+	rssi     float64 // Mean RSSI of the readings (-100 to 0 dBm).
+	samples  int     // Number of readings in the window (at least 1).
+	variance float64 // Variance of the readings in dBm².
+}
+
+// SingleReading returns the Signal of a lone RSSI reading, which has no variance.
+func SingleReading(rssi int32) Signal {
+	return Signal{rssi: float64(rssi), samples: 1}
+}
+
+// NewSignalFromReadings creates a new Signal from the RSSI readings of a window.
+// Returns an error if there are no readings or any reading is outside -100 to 0 dBm.
+func NewSignalFromReadings(readings []int32) (Signal, error) {
+	if len(readings) == 0 {
+		return Signal{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "at least one reading is required")
+	}
+	var sum float64
+	for _, rssi := range readings {
+		if rssi < -100 || rssi > 0 {
+			return Signal{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "rssi must be between -100 and 0 dBm, got %d", rssi)
+		}
+		sum += float64(rssi)
+	}
+	mean := sum / float64(len(readings))
+	var squares float64
+	for _, rssi := range readings {
+		squares += (float64(rssi) - mean) * (float64(rssi) - mean)
+	}
+	return Signal{rssi: mean, samples: len(readings), variance: squares / float64(len(readings))}, nil
+}
+
+// RSSI returns the smoothed RSSI in dBm.
+func (s Signal) RSSI() float64 {
+	return s.rssi
+}
+
+// Samples returns the number of readings the signal was smoothed over.
+func (s Signal) Samples() int {
+	return s.samples
+}
+
+// Variance returns the variance of the readings in dBm².
+func (s Signal) Variance() float64 {
+	return s.variance
+}
+
+// StdDev returns the standard deviation of the readings in dBm.
+func (s Signal) StdDev() float64 {
+	return math.Sqrt(s.variance)
+}
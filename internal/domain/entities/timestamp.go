@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// ParseDetectedAt parses the detection timestamp reported with a request, in ISO 8601
+// (RFC 3339) format, e.g. "2025-03-02T12:00:00Z". An empty value yields the zero time,
+// meaning the detection time is unknown.
+// Returns an error if the value is malformed.
+func ParseDetectedAt(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	detectedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, domainerr.Errorf(domainerr.ErrInvalidArgument, "timestamp must be in ISO 8601 format (e.g. 2025-03-02T12:00:00Z), got %q", value)
+	}
+	return detectedAt.UTC(), nil
+}
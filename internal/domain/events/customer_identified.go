@@ -0,0 +1,99 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// TypeCustomerIdentified is the event_type of CustomerIdentified events.
+const TypeCustomerIdentified = "CustomerIdentified"
+
+// CustomerIdentified is the envelope published after a successful identification.
+// Its JSON form follows the CustomerIdentified schema in docs/event-model.md.
+type CustomerIdentified struct {
+	Envelope                        // Header; EventType is always TypeCustomerIdentified
+	Data     CustomerIdentifiedData `json:"data"` // Event payload
+}
+
+// CustomerIdentifiedData is the payload of a CustomerIdentified event.
+type CustomerIdentifiedData struct {
+	CustomerID   string                `json:"customer_id"`             // Identified customer
+	StoreID      string                `json:"store_id"`                // Store the customer was identified in
+	Source       string                `json:"source"`                  // Identification channel ("beacon" or "qr")
+	Location     string                `json:"location"`                // Location of the beacon or QR code (e.g., "Table 3")
+	LocationType entities.LocationType `json:"location_type,omitempty"` // Type of the location (entrance, table, counter); omitted if unknown
+	Confidence   float32               `json:"confidence"`              // Identification confidence (0.0 to 1.0)
+	Beacon       *BeaconBlock          `json:"beacon,omitempty"`        // Beacon signal that led to the identification, for beacon identifications
+	QR           *QRBlock              `json:"qr,omitempty"`            // Scanned code that led to the identification, for QR identifications
+	DetectedAt   time.Time             `json:"detected_at"`             // Time the signal was detected or the code scanned (UTC)
+}
+
+// BeaconBlock describes the beacon signal carried in event payloads.
+type BeaconBlock struct {
+	UUID  string `json:"uuid"`
+	Major int32  `json:"major"`
+	Minor int32  `json:"minor"`
+	RSSI  int32  `json:"rssi"`
+}
+
+// QRBlock describes the scanned QR code carried in event payloads.
+type QRBlock struct {
+	Nonce string `json:"nonce"` // Identifies the printed code
+}
+
+// NewCustomerIdentified creates a CustomerIdentified event for identity, which was
+// produced from beaconData. A fresh event ID is generated for every call.
+// Returns an error if identity is missing.
+func NewCustomerIdentified(identity *aggregates.CustomerIdentity, beaconData entities.BeaconData) (CustomerIdentified, error) {
+	if identity == nil {
+		return CustomerIdentified{}, fmt.Errorf("identity is required")
+	}
+	event := newCustomerIdentified(identity)
+	event.Data.Beacon = &BeaconBlock{
+		UUID:  beaconData.UUID(),
+		Major: beaconData.Major(),
+		Minor: beaconData.Minor(),
+		RSSI:  beaconData.RSSI(),
+	}
+	return event, nil
+}
+
+// NewQRCustomerIdentified creates a CustomerIdentified event for identity, which was
+// produced from the scanned code qr. A fresh event ID is generated for every call.
+// Returns an error if identity is missing.
+func NewQRCustomerIdentified(identity *aggregates.CustomerIdentity, qr entities.QRData) (CustomerIdentified, error) {
+	if identity == nil {
+		return CustomerIdentified{}, fmt.Errorf("identity is required")
+	}
+	event := newCustomerIdentified(identity)
+	event.Data.QR = &QRBlock{Nonce: qr.Nonce()}
+	return event, nil
+}
+
+// newCustomerIdentified creates the envelope shared by all identification channels.
+func newCustomerIdentified(identity *aggregates.CustomerIdentity) CustomerIdentified {
+	return CustomerIdentified{
+		Envelope: newEnvelope(TypeCustomerIdentified),
+		Data: CustomerIdentifiedData{
+			CustomerID:   identity.CustomerID,
+			StoreID:      identity.StoreID,
+			Source:       string(identity.GetSource()),
+			Location:     identity.Location.Name(),
+			LocationType: identity.Location.Type(),
+			Confidence:   identity.Confidence,
+			DetectedAt:   identity.DetectedAt.UTC(),
+		},
+	}
+}
+
+// Key returns the partitioning key of the event. Events are keyed by customer ID so
+// that all events of one customer are delivered in order.
+func (e CustomerIdentified) Key() string {
+	return e.Data.CustomerID
+}
+
+// Verify interfaces are implemented
+var _ Event = CustomerIdentified{}
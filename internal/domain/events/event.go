@@ -0,0 +1,44 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VersionV1 is the current schema version of the event envelope.
+const VersionV1 = "v1"
+
+// Event is implemented by every event published through the transactional outbox.
+type Event interface {
+	// Header returns the envelope fields shared by all events.
+	Header() Envelope
+
+	// Key returns the partitioning key of the event; events with the same key are
+	// delivered in order.
+	Key() string
+}
+
+// Envelope holds the header shared by all events. It is embedded in every event, so that
+// its fields appear at the top level of the event's JSON form next to "data".
+type Envelope struct {
+	EventID   string    `json:"event_id"`   // Unique event identifier (UUID), used by consumers for deduplication
+	EventType string    `json:"event_type"` // Name of the event (e.g., "CustomerIdentified")
+	Timestamp time.Time `json:"timestamp"`  // Time the event was created (UTC)
+	Version   string    `json:"version"`    // Envelope schema version
+}
+
+// Header returns the envelope itself.
+func (e Envelope) Header() Envelope {
+	return e
+}
+
+// newEnvelope creates the header of a new event of eventType with a fresh event ID.
+func newEnvelope(eventType string) Envelope {
+	return Envelope{
+		EventID:   uuid.NewString(),
+		EventType: eventType,
+		Timestamp: time.Now().UTC(),
+		Version:   VersionV1,
+	}
+}
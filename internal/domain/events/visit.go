@@ -0,0 +1,189 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+const (
+	// TypeVisitStarted is the event_type of VisitStarted events.
+	TypeVisitStarted = "VisitStarted"
+
+	// TypeLocationChanged is the event_type of LocationChanged events.
+	TypeLocationChanged = "LocationChanged"
+
+	// TypeVisitEnded is the event_type of VisitEnded events.
+	TypeVisitEnded = "VisitEnded"
+)
+
+// StayBlock describes a stay of a visit carried in event payloads.
+type StayBlock struct {
+	Location        string                `json:"location"`                // Location of the stay (e.g., "Table 5"); empty if unknown
+	LocationType    entities.LocationType `json:"location_type,omitempty"` // Type of the location (entrance, table, counter); omitted if unknown
+	Since           time.Time             `json:"since"`                   // Time the customer was first identified at the location (UTC)
+	Until           time.Time             `json:"until"`                   // Time the customer moved on or was last seen at the location (UTC)
+	DurationSeconds int64                 `json:"duration_seconds"`        // Time spent at the location
+}
+
+// newStayBlock describes stay for event payloads.
+func newStayBlock(stay aggregates.Stay) StayBlock {
+	return StayBlock{
+		Location:        stay.Location.Name(),
+		LocationType:    stay.Location.Type(),
+		Since:           stay.Since.UTC(),
+		Until:           stay.Until.UTC(),
+		DurationSeconds: int64(stay.Duration() / time.Second),
+	}
+}
+
+// VisitStarted is the envelope published when a customer is first identified at a store.
+// Its JSON form follows the VisitStarted schema in docs/event-model.md.
+type VisitStarted struct {
+	Envelope                  // Header; EventType is always TypeVisitStarted
+	Data     VisitStartedData `json:"data"` // Event payload
+}
+
+// VisitStartedData is the payload of a VisitStarted event.
+type VisitStartedData struct {
+	VisitID      string                `json:"visit_id"`                // Started visit
+	CustomerID   string                `json:"customer_id"`             // Visiting customer
+	StoreID      string                `json:"store_id"`                // Store visited
+	Location     string                `json:"location"`                // Location the customer was first identified at; empty if unknown
+	LocationType entities.LocationType `json:"location_type,omitempty"` // Type of the location; omitted if unknown
+	StartedAt    time.Time             `json:"started_at"`              // Time of the first identification of the visit (UTC)
+}
+
+// NewVisitStarted creates a VisitStarted event for visit, which has just been started.
+// A fresh event ID is generated for every call.
+// Returns an error if visit is missing.
+func NewVisitStarted(visit *aggregates.Visit) (VisitStarted, error) {
+	if visit == nil {
+		return VisitStarted{}, fmt.Errorf("visit is required")
+	}
+	first := visit.Stays[0]
+	return VisitStarted{
+		Envelope: newEnvelope(TypeVisitStarted),
+		Data: VisitStartedData{
+			VisitID:      visit.VisitID,
+			CustomerID:   visit.CustomerID,
+			StoreID:      visit.StoreID,
+			Location:     first.Location.Name(),
+			LocationType: first.Location.Type(),
+			StartedAt:    visit.StartedAt.UTC(),
+		},
+	}, nil
+}
+
+// Key returns the partitioning key of the event. Visit events are keyed by customer ID,
+// like CustomerIdentified, so that all events of one customer are delivered in order.
+func (e VisitStarted) Key() string {
+	return e.Data.CustomerID
+}
+
+// LocationChanged is the envelope published when a customer on a visit is identified at
+// another location of the store.
+// Its JSON form follows the LocationChanged schema in docs/event-model.md.
+type LocationChanged struct {
+	Envelope                     // Header; EventType is always TypeLocationChanged
+	Data     LocationChangedData `json:"data"` // Event payload
+}
+
+// LocationChangedData is the payload of a LocationChanged event.
+type LocationChangedData struct {
+	VisitID    string    `json:"visit_id"`    // Visit the customer is on
+	CustomerID string    `json:"customer_id"` // Moving customer
+	StoreID    string    `json:"store_id"`    // Store visited
+	From       StayBlock `json:"from"`        // Stay the customer left, e.g. 40 minutes at "Table 5"
+	To         StayBlock `json:"to"`          // Location the customer was identified at
+	ChangedAt  time.Time `json:"changed_at"`  // Time the customer was identified at the new location (UTC)
+}
+
+// NewLocationChanged creates a LocationChanged event for visit, whose customer has just
+// moved to the location of its current stay.
+// A fresh event ID is generated for every call.
+// Returns an error if visit is missing or has no previous stay.
+func NewLocationChanged(visit *aggregates.Visit) (LocationChanged, error) {
+	if visit == nil {
+		return LocationChanged{}, fmt.Errorf("visit is required")
+	}
+	if len(visit.Stays) < 2 {
+		return LocationChanged{}, fmt.Errorf("visit %s has not changed location", visit.VisitID)
+	}
+	from, to := visit.Stays[len(visit.Stays)-2], visit.CurrentStay()
+	return LocationChanged{
+		Envelope: newEnvelope(TypeLocationChanged),
+		Data: LocationChangedData{
+			VisitID:    visit.VisitID,
+			CustomerID: visit.CustomerID,
+			StoreID:    visit.StoreID,
+			From:       newStayBlock(from),
+			To:         newStayBlock(to),
+			ChangedAt:  to.Since.UTC(),
+		},
+	}, nil
+}
+
+// Key returns the partitioning key of the event, the customer ID.
+func (e LocationChanged) Key() string {
+	return e.Data.CustomerID
+}
+
+// VisitEnded is the envelope published when a visit ends because the customer has not
+// been identified for the visit timeout.
+// Its JSON form follows the VisitEnded schema in docs/event-model.md.
+type VisitEnded struct {
+	Envelope                // Header; EventType is always TypeVisitEnded
+	Data     VisitEndedData `json:"data"` // Event payload
+}
+
+// VisitEndedData is the payload of a VisitEnded event.
+type VisitEndedData struct {
+	VisitID         string      `json:"visit_id"`         // Ended visit
+	CustomerID      string      `json:"customer_id"`      // Visiting customer
+	StoreID         string      `json:"store_id"`         // Store visited
+	StartedAt       time.Time   `json:"started_at"`       // Time of the first identification of the visit (UTC)
+	EndedAt         time.Time   `json:"ended_at"`         // Time of the last identification of the visit (UTC)
+	DurationSeconds int64       `json:"duration_seconds"` // Length of the visit
+	Stays           []StayBlock `json:"stays"`            // Locations in the order visited
+}
+
+// NewVisitEnded creates a VisitEnded event for visit, which has just ended.
+// A fresh event ID is generated for every call.
+// Returns an error if visit is missing or still open.
+func NewVisitEnded(visit *aggregates.Visit) (VisitEnded, error) {
+	if visit == nil {
+		return VisitEnded{}, fmt.Errorf("visit is required")
+	}
+	if visit.IsOpen() {
+		return VisitEnded{}, fmt.Errorf("visit %s has not ended", visit.VisitID)
+	}
+	stays := make([]StayBlock, 0, len(visit.Stays))
+	for _, stay := range visit.Stays {
+		stays = append(stays, newStayBlock(stay))
+	}
+	return VisitEnded{
+		Envelope: newEnvelope(TypeVisitEnded),
+		Data: VisitEndedData{
+			VisitID:         visit.VisitID,
+			CustomerID:      visit.CustomerID,
+			StoreID:         visit.StoreID,
+			StartedAt:       visit.StartedAt.UTC(),
+			EndedAt:         visit.EndedAt.UTC(),
+			DurationSeconds: int64(visit.Duration() / time.Second),
+			Stays:           stays,
+		},
+	}, nil
+}
+
+// Key returns the partitioning key of the event, the customer ID.
+func (e VisitEnded) Key() string {
+	return e.Data.CustomerID
+}
+
+// Verify interfaces are implemented
+var _ Event = VisitStarted{}
+var _ Event = LocationChanged{}
+var _ Event = VisitEnded{}
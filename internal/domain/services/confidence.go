@@ -0,0 +1,145 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// Default parameters of the log-distance path-loss model.
+const (
+	DefaultPathLossExponent = 2.0  // Free-space propagation
+	DefaultNearDistance     = 2.0  // Meters within which a detection is certain
+	DefaultFarDistance      = 10.0 // Meters beyond which a detection is discarded
+)
+
+// ConfidenceEstimator estimates how likely a beacon detection places the customer at
+// the beacon's location.
+type ConfidenceEstimator interface {
+	// Estimate returns a confidence between 0.0 and 1.0 for a detection of beacon with signal.
+	Estimate(beacon *entities.Beacon, signal entities.Signal) float32
+}
+
+// LinearEstimator maps RSSI linearly to confidence, ignoring the beacon's calibration:
+// -100 dBm yields 0.0, -50 dBm 0.5 and 0 dBm 1.0.
+type LinearEstimator struct{}
+
+// Estimate implements ConfidenceEstimator.
+func (LinearEstimator) Estimate(_ *entities.Beacon, signal entities.Signal) float32 {
+	return clampConfidence((signal.RSSI() + 100) / 100.0)
+}
+
+// PathLossEstimator estimates the distance to the beacon with the log-distance path-loss
+// model, d = 10^((txPower - rssi) / (10 * n)), where txPower is the beacon's calibrated
+// RSSI at 1 meter and n the path-loss exponent of the environment (2 in free space,
+// 2.7 to 4 indoors). Confidence is 1.0 up to the near distance and falls linearly to
+// 0.0 at the far distance.
+type PathLossEstimator struct {
+	exponent float64 // Path-loss exponent n
+	near     float64 // Distance in meters up to which confidence is 1.0
+	far      float64 // Distance in meters from which confidence is 0.0
+}
+
+// NewPathLossEstimator creates a new PathLossEstimator.
+// Returns an error if exponent is not positive or the distances do not satisfy
+// 0 <= nearDistance < farDistance.
+func NewPathLossEstimator(exponent, nearDistance, farDistance float64) (*PathLossEstimator, error) {
+	if exponent <= 0 {
+		return nil, fmt.Errorf("path-loss exponent must be positive, got %g", exponent)
+	}
+	if nearDistance < 0 || nearDistance >= farDistance {
+		return nil, fmt.Errorf("near distance must be non-negative and below far distance, got %g and %g", nearDistance, farDistance)
+	}
+	return &PathLossEstimator{exponent: exponent, near: nearDistance, far: farDistance}, nil
+}
+
+// Distance returns the estimated distance in meters between the device and beacon.
+func (e *PathLossEstimator) Distance(beacon *entities.Beacon, rssi float64) float64 {
+	return math.Pow(10, (float64(beacon.CalibratedTxPower())-rssi)/(10*e.exponent))
+}
+
+// Estimate implements ConfidenceEstimator.
+func (e *PathLossEstimator) Estimate(beacon *entities.Beacon, signal entities.Signal) float32 {
+	distance := e.Distance(beacon, signal.RSSI())
+	return clampConfidence(1 - (distance-e.near)/(e.far-e.near))
+}
+
+// StoreEstimator selects the ConfidenceEstimator configured for the store of each beacon,
+// falling back to a default one. Store IDs are matched case-insensitively.
+type StoreEstimator struct {
+	fallback ConfidenceEstimator            // Estimator for stores without an override
+	stores   map[string]ConfidenceEstimator // Overrides keyed by lower-cased store ID
+}
+
+// NewStoreEstimator creates a new StoreEstimator using fallback for every store not in stores.
+// Returns an error if fallback or any override is nil.
+func NewStoreEstimator(fallback ConfidenceEstimator, stores map[string]ConfidenceEstimator) (*StoreEstimator, error) {
+	if fallback == nil {
+		return nil, fmt.Errorf("default confidence estimator is required")
+	}
+	e := &StoreEstimator{fallback: fallback, stores: make(map[string]ConfidenceEstimator, len(stores))}
+	for storeID, estimator := range stores {
+		if estimator == nil {
+			return nil, fmt.Errorf("confidence estimator for store %s is required", storeID)
+		}
+		e.stores[strings.ToLower(storeID)] = estimator
+	}
+	return e, nil
+}
+
+// Estimate implements ConfidenceEstimator.
+func (e *StoreEstimator) Estimate(beacon *entities.Beacon, signal entities.Signal) float32 {
+	if estimator, ok := e.stores[strings.ToLower(beacon.StoreID)]; ok {
+		return estimator.Estimate(beacon, signal)
+	}
+	return e.fallback.Estimate(beacon, signal)
+}
+
+// StabilityEstimator discounts the confidence of another estimator for unstable signals.
+// The confidence is kept while the standard deviation of the readings is within the
+// tolerance and falls linearly to 0.0 at twice the tolerance.
+type StabilityEstimator struct {
+	next      ConfidenceEstimator // Estimator of the undiscounted confidence
+	tolerance float64             // Standard deviation in dBm up to which signals are stable
+}
+
+// NewStabilityEstimator creates a new StabilityEstimator discounting the confidence of next.
+// Returns an error if next is nil or tolerance is not positive.
+func NewStabilityEstimator(next ConfidenceEstimator, tolerance float64) (*StabilityEstimator, error) {
+	if next == nil {
+		return nil, fmt.Errorf("confidence estimator is required")
+	}
+	if tolerance <= 0 {
+		return nil, fmt.Errorf("standard deviation tolerance must be positive, got %g", tolerance)
+	}
+	return &StabilityEstimator{next: next, tolerance: tolerance}, nil
+}
+
+// Estimate implements ConfidenceEstimator.
+func (e *StabilityEstimator) Estimate(beacon *entities.Beacon, signal entities.Signal) float32 {
+	confidence := e.next.Estimate(beacon, signal)
+	excess := signal.StdDev() - e.tolerance
+	if excess <= 0 {
+		return confidence
+	}
+	return clampConfidence(float64(confidence) * (1 - excess/e.tolerance))
+}
+
+// clampConfidence converts v to a confidence between 0.0 and 1.0.
+func clampConfidence(v float64) float32 {
+	if v < 0.0 {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return float32(v)
+}
+
+// Verify interfaces are implemented
+var _ ConfidenceEstimator = LinearEstimator{}
+var _ ConfidenceEstimator = (*PathLossEstimator)(nil)
+var _ ConfidenceEstimator = (*StoreEstimator)(nil)
+var _ ConfidenceEstimator = (*StabilityEstimator)(nil)
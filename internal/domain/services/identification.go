@@ -1,134 +1,588 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
 	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
 )
 
+// ErrCustomerNotIdentified is returned when identification is rejected by a domain rule
+// (unknown beacon, or a beacon status, confidence or detection time not allowed by the
+// store's IdentificationPolicy) rather than by an infrastructure failure. Such errors are
+// also marked with the domainerr kind of the rule, e.g. domainerr.ErrLowConfidence.
+var ErrCustomerNotIdentified = errors.New("customer not identified")
+
+// Outcome describes how a successful identification request was resolved.
+type Outcome string
+
+const (
+	// OutcomeIdentified indicates the customer was identified and the identification recorded.
+	OutcomeIdentified Outcome = "identified"
+	// OutcomeAlreadyIdentified indicates the customer was identified within the dedupe
+	// window of the store's policy, so nothing was recorded.
+	OutcomeAlreadyIdentified Outcome = "already_identified"
+)
+
+// Identification is the result of a successful identification request.
+type Identification struct {
+	Identity *aggregates.CustomerIdentity // New identity, or the earlier one if already identified
+	Outcome  Outcome                      // How the request was resolved
+}
+
 // IdentificationService defines the interface for customer identification logic.
-// It provides methods to identify customers based on beacon data.
+// It provides methods to identify customers based on beacon data or scanned QR codes.
+// Cancellation and deadlines of ctx are honoured by every storage call.
 type IdentificationService interface {
-	IdentifyCustomer(beaconData entities.BeaconData) (*aggregates.CustomerIdentity, error)
+	// IdentifyCustomer identifies the customer using the device deviceID, detected by beaconData.
+	IdentifyCustomer(ctx context.Context, deviceID entities.DeviceID, beaconData entities.BeaconData) (*Identification, error)
+
+	// IdentifyScan identifies the customer using the device deviceID at the beacon it is
+	// nearest to among all beacons detected in scan.
+	IdentifyScan(ctx context.Context, deviceID entities.DeviceID, scan entities.BeaconScan) (*Identification, error)
+
+	// IdentifyByQR identifies the customer using the device deviceID, which scanned qr.
+	// qr must come from a payload whose signature has been verified.
+	IdentifyByQR(ctx context.Context, deviceID entities.DeviceID, qr entities.QRData) (*Identification, error)
 }
 
 // identificationService implements the IdentificationService interface.
 // It orchestrates customer identification by validating beacon data, retrieving or creating
 // customer and beacon entities, and enforcing domain rules.
 type identificationService struct {
-	customerRepo CustomerRepository // Repository for customer data access
-	beaconRepo   BeaconRepository   // Repository for beacon data access
+	customerRepo ports.CustomerRepository           // Repository for customer data access
+	beaconRepo   ports.BeaconRepository             // Repository for beacon data access
+	deviceRepo   ports.CustomerDeviceRepository     // Repository binding devices to customers
+	recorder     ports.IdentificationRecorder       // Optional transactional recorder of identifications and their events
+	estimator    ConfidenceEstimator                // Estimates the confidence of beacon detections
+	smoother     ports.SignalSmoother               // Optional smoother of readings per device/beacon pair
+	resolver     *NearestBeaconResolver             // Picks the nearest beacon of a scan
+	placements   ports.NearestBeaconRepository      // Optional memory of the beacon each device is placed at
+	policies     *aggregates.IdentificationPolicies // Identification rules of each store
+	identities   ports.IdentityCache                // Optional lookup of the latest identity of each customer
+	visits       ports.VisitRepository              // Optional repository of the visits customers are on
+}
+
+// Option configures optional dependencies of the identification service.
+type Option func(*identificationService) error
+
+// WithIdentificationRecorder records every successful identification, together with its
+// CustomerIdentified event, through recorder instead of only updating the customer.
+func WithIdentificationRecorder(recorder ports.IdentificationRecorder) Option {
+	return func(s *identificationService) error {
+		if recorder == nil {
+			return fmt.Errorf("identification recorder is required")
+		}
+		s.recorder = recorder
+		return nil
+	}
 }
 
-// CustomerRepository defines the interface for customer data operations.
-// This abstraction allows decoupling from specific storage implementations.
-type CustomerRepository interface {
-	FindByID(customerID string) (*entities.Customer, error)
-	Save(customer *entities.Customer) error
+// WithConfidenceEstimator estimates the confidence of beacon detections with estimator
+// instead of the default log-distance path-loss model.
+func WithConfidenceEstimator(estimator ConfidenceEstimator) Option {
+	return func(s *identificationService) error {
+		if estimator == nil {
+			return fmt.Errorf("confidence estimator is required")
+		}
+		s.estimator = estimator
+		return nil
+	}
 }
 
-// BeaconRepository defines the interface for beacon data operations.
-// This abstraction supports querying and updating beacon entities.
-type BeaconRepository interface {
-	FindByUUID(uuid string) (*entities.Beacon, error)
+// WithSignalSmoother estimates the confidence of beacon detections from the readings of
+// the device/beacon pair smoothed by smoother instead of from the latest reading alone.
+func WithSignalSmoother(smoother ports.SignalSmoother) Option {
+	return func(s *identificationService) error {
+		if smoother == nil {
+			return fmt.Errorf("signal smoother is required")
+		}
+		s.smoother = smoother
+		return nil
+	}
+}
+
+// WithNearestBeaconResolver resolves the nearest beacon of scans with resolver, applying its
+// hysteresis to the beacon each device was last placed at according to placements.
+func WithNearestBeaconResolver(resolver *NearestBeaconResolver, placements ports.NearestBeaconRepository) Option {
+	return func(s *identificationService) error {
+		if resolver == nil {
+			return fmt.Errorf("nearest beacon resolver is required")
+		}
+		if placements == nil {
+			return fmt.Errorf("nearest beacon repository is required")
+		}
+		s.resolver = resolver
+		s.placements = placements
+		return nil
+	}
+}
+
+// WithIdentificationPolicies applies the rules of policies to each store instead of
+// aggregates.DefaultIdentificationPolicy.
+func WithIdentificationPolicies(policies *aggregates.IdentificationPolicies) Option {
+	return func(s *identificationService) error {
+		if policies == nil {
+			return fmt.Errorf("identification policies are required")
+		}
+		s.policies = policies
+		return nil
+	}
+}
+
+// WithIdentityCache reports customers whose latest identification in identities lies
+// within the dedupe window as already identified instead of identifying them again.
+// Without it, duplicates are not detected. The cache is populated by the caller, so that
+// cache failures never fail an identification that has already been recorded.
+func WithIdentityCache(identities ports.IdentityCache) Option {
+	return func(s *identificationService) error {
+		if identities == nil {
+			return fmt.Errorf("identity cache is required")
+		}
+		s.identities = identities
+		return nil
+	}
+}
+
+// WithVisitTracking groups the identifications of each customer into visits, read from
+// visits and saved by the recorder, which is required. The first identification at a
+// store starts a visit, identifications at another location move the customer, and a
+// visit whose customer was not identified for the store's visit timeout ends with the
+// next identification; visits of customers who do not return are ended by the visit
+// sweeper (see visits.Sweeper).
+func WithVisitTracking(visits ports.VisitRepository) Option {
+	return func(s *identificationService) error {
+		if visits == nil {
+			return fmt.Errorf("visit repository is required")
+		}
+		s.visits = visits
+		return nil
+	}
 }
 
 // NewIdentificationService creates a new instance of identificationService.
-// It requires customer and beacon repositories to perform identification.
+// It requires customer, beacon and device repositories to perform identification.
 // Returns an error if dependencies are invalid.
-func NewIdentificationService(customerRepo CustomerRepository, beaconRepo BeaconRepository) (IdentificationService, error) {
+func NewIdentificationService(customerRepo ports.CustomerRepository, beaconRepo ports.BeaconRepository, deviceRepo ports.CustomerDeviceRepository, opts ...Option) (IdentificationService, error) {
 	if customerRepo == nil {
 		return nil, fmt.Errorf("customer repository is required")
 	}
 	if beaconRepo == nil {
 		return nil, fmt.Errorf("beacon repository is required")
 	}
-	return &identificationService{
+	if deviceRepo == nil {
+		return nil, fmt.Errorf("device repository is required")
+	}
+	policies, err := aggregates.NewIdentificationPolicies(aggregates.DefaultIdentificationPolicy(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create default identification policies: %w", err)
+	}
+	s := &identificationService{
 		customerRepo: customerRepo,
 		beaconRepo:   beaconRepo,
-	}, nil
+		deviceRepo:   deviceRepo,
+		estimator: &PathLossEstimator{
+			exponent: DefaultPathLossExponent,
+			near:     DefaultNearDistance,
+			far:      DefaultFarDistance,
+		},
+		resolver: &NearestBeaconResolver{margin: DefaultHysteresisMargin},
+		policies: policies,
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+	if s.visits != nil && s.recorder == nil {
+		return nil, fmt.Errorf("visit tracking requires an identification recorder")
+	}
+	return s, nil
 }
 
 // IdentifyCustomer identifies a customer based on the provided beacon data.
-// It retrieves or creates the associated customer and beacon entities, calculates
-// identification confidence, and enforces domain rules (e.g., minimum confidence, no duplicates).
-// Returns a CustomerIdentity instance or an error if identification fails.
-func (s *identificationService) IdentifyCustomer(beaconData entities.BeaconData) (*aggregates.CustomerIdentity, error) {
-	// Validate beacon data
+// The customer is the one deviceID is bound to; a device seen for the first time is
+// bound to a new anonymous customer. Rules are those of the IdentificationPolicy of the
+// beacon's store. It retrieves the beacon entity, calculates
+// identification confidence from the signal of the device/beacon pair, smoothed over recent
+// readings when a smoother is configured, and enforces domain rules (e.g., minimum
+// confidence). A customer identified within the dedupe window is reported as already
+// identified, with the earlier identity. The detection time is the one reported with
+// beaconData, or the current time if none was; it must lie within the clock skew and
+// detection age allowed by the policy.
+// Returns the Identification or an error if identification fails; storage errors are
+// marked domainerr.ErrStorage and, when caused by ctx, wrap context.Canceled or
+// context.DeadlineExceeded.
+func (s *identificationService) IdentifyCustomer(ctx context.Context, deviceID entities.DeviceID, beaconData entities.BeaconData) (*Identification, error) {
+	// Validate request data
+	if err := deviceID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid device ID: %w", err)
+	}
 	if err := beaconData.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid beacon data: %w", err)
 	}
 
 	// Retrieve beacon entity
-	beacon, err := s.beaconRepo.FindByUUID(beaconData.UUID())
+	beacon, err := s.beaconRepo.FindByUUID(ctx, beaconData.UUID())
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve beacon: %w", err)
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve beacon: %w", err)
 	}
 	if beacon == nil {
-		return nil, fmt.Errorf("beacon not found for UUID: %s", beaconData.UUID())
+		return nil, domainerr.Errorf(domainerr.ErrBeaconNotFound, "%w: beacon not found for UUID: %s", ErrCustomerNotIdentified, beaconData.UUID())
+	}
+	policy := s.policies.For(beacon.StoreID)
+	if !policy.AllowsBeaconStatus(beacon.Status) {
+		return nil, domainerr.Errorf(domainerr.ErrBeaconInactive, "%w: beacon %s is not active, current status: %s", ErrCustomerNotIdentified, beacon.BeaconID, beacon.Status)
+	}
+	beaconData, err = checkDetectedAt(policy, beaconData)
+	if err != nil {
+		return nil, err
+	}
+
+	signal, err := s.smooth(ctx, deviceID, beacon, beaconData)
+	if err != nil {
+		return nil, err
+	}
+	return s.identifyAt(ctx, deviceID, BeaconCandidate{Beacon: beacon, Reading: beaconData, Signal: signal})
+}
+
+// IdentifyScan identifies a customer based on all beacons detected in a scan.
+// The customer is resolved from deviceID as in IdentifyCustomer. Unknown beacons and
+// beacons whose status the store's policy does not allow are ignored; among the others, the beacon with the strongest smoothed signal
+// is chosen, unless the device is already placed at another beacon of the scan whose
+// signal is within the hysteresis margin. The chosen beacon is then subject to the same
+// rules as in IdentifyCustomer, and the device is placed at it only if it identifies the
+// customer. A scan with a detection time the policy of any of its beacons does not allow
+// is rejected.
+// Returns the Identification or an error if identification fails.
+func (s *identificationService) IdentifyScan(ctx context.Context, deviceID entities.DeviceID, scan entities.BeaconScan) (*Identification, error) {
+	// Validate request data
+	if err := deviceID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid device ID: %w", err)
+	}
+	if err := scan.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid beacon scan: %w", err)
+	}
+
+	// Smooth the signal of every known beacon of the scan allowed by its store's policy
+	var candidates []BeaconCandidate
+	for _, reading := range scan.Readings() {
+		beacon, err := s.beaconRepo.FindByUUID(ctx, reading.UUID())
+		if err != nil {
+			return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve beacon: %w", err)
+		}
+		if beacon == nil {
+			continue
+		}
+		policy := s.policies.For(beacon.StoreID)
+		if !policy.AllowsBeaconStatus(beacon.Status) {
+			continue
+		}
+		if reading, err = checkDetectedAt(policy, reading); err != nil {
+			return nil, err
+		}
+		signal, err := s.smooth(ctx, deviceID, beacon, reading)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, BeaconCandidate{Beacon: beacon, Reading: reading, Signal: signal})
+	}
+
+	// Pick the nearest beacon, sticking to the current placement within the margin
+	var current string
+	if s.placements != nil {
+		var err error
+		if current, err = s.placements.FindNearestBeacon(ctx, deviceID); err != nil {
+			return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve nearest beacon: %w", err)
+		}
 	}
-	if beacon.Status != entities.StatusActive {
-		return nil, fmt.Errorf("beacon %s is not active, current status: %s", beacon.BeaconID, beacon.Status)
+	nearest, ok := s.resolver.Resolve(candidates, current)
+	if !ok {
+		return nil, domainerr.Errorf(domainerr.ErrBeaconNotFound, "%w: no known active beacon in scan", ErrCustomerNotIdentified)
 	}
 
-	// Simple confidence calculation based on RSSI (production would use more sophisticated logic)
-	confidence := calculateConfidence(beaconData.RSSI())
-	if confidence < 0.8 {
-		return nil, fmt.Errorf("identification confidence %f below minimum threshold of 0.8", confidence)
+	// Only a beacon that identified the customer becomes the placement, so that rejected
+	// scans do not bias the next one
+	result, err := s.identifyAt(ctx, deviceID, nearest)
+	if err != nil {
+		return nil, err
 	}
+	if s.placements != nil && result.Outcome == OutcomeIdentified {
+		if err := s.placements.SaveNearestBeacon(ctx, deviceID, nearest.Beacon.BeaconID); err != nil {
+			return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to save nearest beacon: %w", err)
+		}
+	}
+	return result, nil
+}
 
-	// Retrieve or create customer (simplified logic for initial implementation)
-	customerID := GenerateCustomerID(beaconData) // Placeholder for actual logic
-	customer, err := s.customerRepo.FindByID(customerID)
+// checkDetectedAt returns reading detected at the time it reports, or at the current time
+// if it reports none, provided policy allows that time.
+func checkDetectedAt(policy aggregates.IdentificationPolicy, reading entities.BeaconData) (entities.BeaconData, error) {
+	reading = reading.WithDetectedAt(detectionTime(reading.DetectedAt()))
+	if err := policy.CheckDetectedAt(reading.DetectedAt()); err != nil {
+		return entities.BeaconData{}, fmt.Errorf("%w: %w", ErrCustomerNotIdentified, err)
+	}
+	return reading, nil
+}
+
+// detectionTime returns reported, the detection time reported with a request, or the
+// current time if none was reported.
+func detectionTime(reported time.Time) time.Time {
+	if reported.IsZero() {
+		return time.Now().UTC()
+	}
+	return reported.UTC()
+}
+
+// smooth returns the signal of the deviceID/beacon pair including reading, smoothed over
+// the readings detected shortly before it when a smoother is configured.
+func (s *identificationService) smooth(ctx context.Context, deviceID entities.DeviceID, beacon *entities.Beacon, reading entities.BeaconData) (entities.Signal, error) {
+	if s.smoother == nil {
+		return entities.SingleReading(reading.RSSI()), nil
+	}
+	signal, err := s.smoother.Smooth(ctx, deviceID, beacon.BeaconID, reading.RSSI(), reading.DetectedAt())
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve customer: %w", err)
+		return entities.Signal{}, domainerr.Errorf(domainerr.ErrStorage, "failed to smooth signal: %w", err)
 	}
-	if customer == nil {
-		customer, err = entities.NewCustomer(customerID, nil)
+	return signal, nil
+}
+
+// identifyAt identifies the customer of deviceID at the beacon of candidate, enforcing
+// the minimum confidence and the rules of CustomerIdentity, and records the identification
+// unless the customer was already identified. The candidate's reading carries the
+// detection time.
+func (s *identificationService) identifyAt(ctx context.Context, deviceID entities.DeviceID, candidate BeaconCandidate) (*Identification, error) {
+	policy := s.policies.For(candidate.Beacon.StoreID)
+	detectedAt := candidate.Reading.DetectedAt()
+	confidence := s.estimator.Estimate(candidate.Beacon, candidate.Signal)
+	if confidence < policy.MinConfidence {
+		return nil, domainerr.Errorf(domainerr.ErrLowConfidence, "%w: identification confidence %f below minimum threshold of %g", ErrCustomerNotIdentified, confidence, policy.MinConfidence)
+	}
+
+	// Resolve the customer the reporting device is bound to
+	customer, err := s.resolveCustomer(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.alreadyIdentified(ctx, customer, policy, candidate.Beacon.StoreID, candidate.Beacon.Location, detectedAt)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return duplicate, nil
+	}
+
+	// Create CustomerIdentity with the detection timestamp
+	identity, err := aggregates.NewCustomerIdentity(customer, candidate.Beacon, confidence, detectedAt, policy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create customer identity: %w", ErrCustomerNotIdentified, err)
+	}
+
+	event, err := events.NewCustomerIdentified(identity, candidate.Reading)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer identified event: %w", err)
+	}
+	if err = s.record(ctx, customer, identity, event, policy); err != nil {
+		return nil, err
+	}
+	return &Identification{Identity: identity, Outcome: OutcomeIdentified}, nil
+}
+
+// IdentifyByQR identifies a customer based on a scanned QR code.
+// The customer is resolved from deviceID as in IdentifyCustomer. The code itself
+// places the customer at its store and location with confidence 1.0, so only the
+// detection time and dedupe rules apply. The detection time is the one reported with qr,
+// or the current time if none was.
+// Returns the Identification or an error if identification fails.
+func (s *identificationService) IdentifyByQR(ctx context.Context, deviceID entities.DeviceID, qr entities.QRData) (*Identification, error) {
+	// Validate request data
+	if err := deviceID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid device ID: %w", err)
+	}
+	if err := qr.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid QR data: %w", err)
+	}
+
+	policy := s.policies.For(qr.StoreID())
+	detectedAt := detectionTime(qr.DetectedAt())
+	if err := policy.CheckDetectedAt(detectedAt); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCustomerNotIdentified, err)
+	}
+
+	// Resolve the customer the scanning device is bound to
+	customer, err := s.resolveCustomer(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.alreadyIdentified(ctx, customer, policy, qr.StoreID(), qr.Location(), detectedAt)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return duplicate, nil
+	}
+
+	identity, err := aggregates.NewQRCustomerIdentity(customer, qr, detectedAt, policy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create customer identity: %w", ErrCustomerNotIdentified, err)
+	}
+
+	event, err := events.NewQRCustomerIdentified(identity, qr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer identified event: %w", err)
+	}
+	if err = s.record(ctx, customer, identity, event, policy); err != nil {
+		return nil, err
+	}
+	return &Identification{Identity: identity, Outcome: OutcomeIdentified}, nil
+}
+
+// alreadyIdentified returns the customer's latest identity as an already identified result
+// if an identification at location of storeID at detectedAt would repeat it under policy,
+// and nil otherwise.
+func (s *identificationService) alreadyIdentified(ctx context.Context, customer *entities.Customer, policy aggregates.IdentificationPolicy, storeID string, location entities.Location, detectedAt time.Time) (*Identification, error) {
+	if s.identities == nil {
+		return nil, nil
+	}
+	last, err := s.identities.GetCustomerIdentity(ctx, customer.CustomerID)
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve latest identity: %w", err)
+	}
+	if !policy.IsDuplicate(last, storeID, location, detectedAt) {
+		return nil, nil
+	}
+	return &Identification{Identity: last, Outcome: OutcomeAlreadyIdentified}, nil
+}
+
+// record moves the customer's LastSeen timestamp forward to the detection time of the
+// identity, and saves it together with the identity, the event for downstream services and
+// the visits it changed when a recorder is configured.
+func (s *identificationService) record(ctx context.Context, customer *entities.Customer, identity *aggregates.CustomerIdentity, event events.CustomerIdentified, policy aggregates.IdentificationPolicy) error {
+	customer.SeenAt(identity.DetectedAt)
+	if s.recorder != nil {
+		visits, err := s.trackVisit(ctx, identity, policy)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create new customer: %w", err)
+			return err
 		}
-		if err = s.customerRepo.Save(customer); err != nil {
-			return nil, fmt.Errorf("failed to save new customer: %w", err)
+		if err := s.recorder.RecordIdentification(ctx, customer, identity, event, visits...); err != nil {
+			return domainerr.Errorf(domainerr.ErrStorage, "failed to record identification: %w", err)
 		}
+		return nil
+	}
+	if err := s.customerRepo.Save(ctx, customer); err != nil {
+		return domainerr.Errorf(domainerr.ErrStorage, "failed to update customer last seen: %w", err)
 	}
+	return nil
+}
 
-	// Create CustomerIdentity with current timestamp
-	detectedAt := time.Now().UTC()
-	identity, err := aggregates.NewCustomerIdentity(customer, beacon, confidence, detectedAt)
+// trackVisit applies identity to the visit its customer is on and returns the visits to
+// save with the events they emitted. A visit at another store, or one whose timeout
+// expired before the identification, ends at the time the customer was last seen there,
+// and a new visit starts. Identifications detected before the latest one of the visit
+// leave visits unchanged. Returns nil if visits are not tracked.
+func (s *identificationService) trackVisit(ctx context.Context, identity *aggregates.CustomerIdentity, policy aggregates.IdentificationPolicy) ([]ports.VisitUpdate, error) {
+	if s.visits == nil {
+		return nil, nil
+	}
+	visit, err := s.visits.FindOpenVisit(ctx, identity.CustomerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create customer identity: %w", err)
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve open visit: %w", err)
+	}
+	if visit != nil && identity.DetectedAt.Before(visit.LastSeenAt) {
+		return nil, nil
 	}
 
-	// Update customer's LastSeen timestamp
-	customer.UpdateLastSeen()
-	if err = s.customerRepo.Save(customer); err != nil {
-		return nil, fmt.Errorf("failed to update customer last seen: %w", err)
+	var updates []ports.VisitUpdate
+	if visit != nil && (visit.StoreID != identity.StoreID || visit.IsExpiredAt(identity.DetectedAt)) {
+		ended, err := EndVisit(visit)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, ports.VisitUpdate{Visit: visit, Events: []events.Event{ended}})
+		visit = nil
+	}
+
+	if visit == nil {
+		visit, err = aggregates.StartVisit(identity, policy.VisitTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start visit: %w", err)
+		}
+		started, err := events.NewVisitStarted(visit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create visit started event: %w", err)
+		}
+		return append(updates, ports.VisitUpdate{Visit: visit, Events: []events.Event{started}}), nil
+	}
+
+	moved, err := visit.Record(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record identification in visit: %w", err)
 	}
+	update := ports.VisitUpdate{Visit: visit}
+	if moved {
+		changed, err := events.NewLocationChanged(visit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create location changed event: %w", err)
+		}
+		update.Events = append(update.Events, changed)
+	}
+	return append(updates, update), nil
+}
 
-	return identity, nil
+// EndVisit ends visit at the time its customer was last seen and returns the VisitEnded
+// event to publish.
+// Returns an error if the visit has already ended.
+func EndVisit(visit *aggregates.Visit) (events.VisitEnded, error) {
+	if err := visit.End(); err != nil {
+		return events.VisitEnded{}, fmt.Errorf("failed to end visit: %w", err)
+	}
+	ended, err := events.NewVisitEnded(visit)
+	if err != nil {
+		return events.VisitEnded{}, fmt.Errorf("failed to create visit ended event: %w", err)
+	}
+	return ended, nil
 }
 
-// calculateConfidence computes a simple confidence score based on RSSI.
-// For production, this could integrate more complex algorithms (e.g., distance, signal quality).
-// Returns a value between 0.0 and 1.0, where higher RSSI (closer to 0) yields higher confidence.
-func calculateConfidence(rssi int32) float32 {
-	// Normalize RSSI (-100 to 0) to confidence (0.0 to 1.0)
-	// Example: -100 -> 0.0, -50 -> 0.5, 0 -> 1.0
-	normalized := float32(rssi+100) / 100.0
-	if normalized < 0.0 {
-		return 0.0
+// resolveCustomer returns the customer deviceID is bound to.
+// An unknown device is bound to a new anonymous customer; if another request bound the
+// device concurrently, the customer bound first is used.
+func (s *identificationService) resolveCustomer(ctx context.Context, deviceID entities.DeviceID) (*entities.Customer, error) {
+	customerID, err := s.deviceRepo.FindCustomerID(ctx, deviceID)
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to resolve device: %w", err)
 	}
-	if normalized > 1.0 {
-		return 1.0
+	if customerID == "" {
+		customer, err := entities.NewCustomer(newAnonymousCustomerID(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new customer: %w", err)
+		}
+		customerID, err = s.deviceRepo.BindDevice(ctx, deviceID, customer)
+		if err != nil {
+			return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to bind device: %w", err)
+		}
+		if customerID == customer.CustomerID {
+			return customer, nil
+		}
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve customer: %w", err)
+	}
+	if customer == nil {
+		return nil, fmt.Errorf("customer %s bound to device %s not found", customerID, deviceID)
 	}
-	return normalized
+	return customer, nil
 }
 
-// generateCustomerID generates a simple customer ID based on beacon data.
-// This is a placeholder; production would use a more robust ID generation strategy (e.g., UUID).
-func GenerateCustomerID(beaconData entities.BeaconData) string {
-	return fmt.Sprintf("cust-%s-%d-%d", beaconData.UUID(), beaconData.Major(), beaconData.Minor())
+// newAnonymousCustomerID generates the ID of a customer known only by their devices.
+func newAnonymousCustomerID() string {
+	return "cust-" + uuid.NewString()
 }
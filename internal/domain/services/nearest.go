@@ -0,0 +1,65 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// DefaultHysteresisMargin is the default margin in dB by which another beacon must beat
+// the beacon a device is currently placed at before the device is moved.
+const DefaultHysteresisMargin = 6.0
+
+// BeaconCandidate is a beacon detected in a scan, with the smoothed signal of the detection.
+type BeaconCandidate struct {
+	Beacon  *entities.Beacon    // Known, active beacon
+	Reading entities.BeaconData // Latest detection of the beacon
+	Signal  entities.Signal     // Signal of the device/beacon pair, smoothed over recent readings
+}
+
+// strength returns the smoothed RSSI relative to the beacon's calibrated TxPower, so that
+// beacons transmitting at different powers are compared by their estimated distance.
+func (c BeaconCandidate) strength() float64 {
+	return c.Signal.RSSI() - float64(c.Beacon.CalibratedTxPower())
+}
+
+// NearestBeaconResolver picks the beacon a device is most likely nearest to from a scan.
+// The strongest signal wins, except that the beacon the device is currently placed at is
+// kept until another one is stronger by the margin, which prevents a customer sitting
+// between two tables from flapping between them.
+type NearestBeaconResolver struct {
+	margin float64 // Hysteresis margin in dB
+}
+
+// NewNearestBeaconResolver creates a new NearestBeaconResolver with the hysteresis margin in dB.
+// A margin of 0 disables hysteresis.
+// Returns an error if margin is negative.
+func NewNearestBeaconResolver(margin float64) (*NearestBeaconResolver, error) {
+	if margin < 0 {
+		return nil, fmt.Errorf("hysteresis margin must not be negative, got %g", margin)
+	}
+	return &NearestBeaconResolver{margin: margin}, nil
+}
+
+// Resolve returns the candidate the device is nearest to, given the ID of the beacon it is
+// currently placed at (empty if unknown). Returns false if there are no candidates.
+func (r *NearestBeaconResolver) Resolve(candidates []BeaconCandidate, currentBeaconID string) (BeaconCandidate, bool) {
+	if len(candidates) == 0 {
+		return BeaconCandidate{}, false
+	}
+	nearest := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.strength() > nearest.strength() {
+			nearest = candidate
+		}
+	}
+	if nearest.Beacon.BeaconID == currentBeaconID {
+		return nearest, true
+	}
+	for _, candidate := range candidates {
+		if candidate.Beacon.BeaconID == currentBeaconID && nearest.strength() < candidate.strength()+r.margin {
+			return candidate, true
+		}
+	}
+	return nearest, true
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// Occupancy counts the customers present in a store by zone of its floor layout.
+type Occupancy struct {
+	StoreID string          // Store counted
+	Total   int             // Customers present in the store
+	Zones   []ZoneOccupancy // Customers present in each zone, in layout order, empty zones included
+	Unzoned int             // Customers at locations outside every zone or at unknown locations
+}
+
+// ZoneOccupancy counts the customers present in one zone of a store.
+type ZoneOccupancy struct {
+	Zone  string // Name of the zone (e.g., "Terrace")
+	Floor int    // Floor the zone is on
+	Count int    // Customers present at the locations of the zone
+}
+
+// PresenceService defines the interface for the real-time view of who is in a store.
+// Presence is maintained by identifications: a customer is present from an identification
+// until the visit timeout of the store's policy passes without another one.
+type PresenceService interface {
+	// ListOccupants returns the customers present in storeID, or only those at location if
+	// it is not empty, longest present at their location first.
+	ListOccupants(ctx context.Context, storeID, location string) ([]ports.Occupant, error)
+
+	// GetOccupancy counts the customers present in storeID by zone.
+	GetOccupancy(ctx context.Context, storeID string) (*Occupancy, error)
+}
+
+// presenceService implements the PresenceService interface on top of a presence index.
+type presenceService struct {
+	presence ports.PresenceIndex   // Index of the customers present in each store
+	stores   ports.StoreRepository // Repository resolving the floor layout of stores
+}
+
+// NewPresenceService creates a new PresenceService reading presence from presence and
+// floor layouts from stores.
+// Returns an error if dependencies are invalid.
+func NewPresenceService(presence ports.PresenceIndex, stores ports.StoreRepository) (PresenceService, error) {
+	if presence == nil {
+		return nil, fmt.Errorf("presence index is required")
+	}
+	if stores == nil {
+		return nil, fmt.Errorf("store repository is required")
+	}
+	return &presenceService{
+		presence: presence,
+		stores:   stores,
+	}, nil
+}
+
+// ListOccupants returns the customers present in storeID, or only those at location if it
+// is not empty, longest present at their location first.
+// Returns an ErrStoreNotFound error if the store is not registered.
+func (s *presenceService) ListOccupants(ctx context.Context, storeID, location string) ([]ports.Occupant, error) {
+	if _, err := s.findStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+	occupants, err := s.presence.ListOccupants(ctx, storeID, location, time.Now())
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to list occupants of store %s: %w", storeID, err)
+	}
+	return occupants, nil
+}
+
+// GetOccupancy counts the customers present in storeID by the zone of the location they
+// were last identified at.
+// Returns an ErrStoreNotFound error if the store is not registered.
+func (s *presenceService) GetOccupancy(ctx context.Context, storeID string) (*Occupancy, error) {
+	store, err := s.findStore(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	occupants, err := s.presence.ListOccupants(ctx, storeID, "", time.Now())
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to list occupants of store %s: %w", storeID, err)
+	}
+
+	occupancy := &Occupancy{
+		StoreID: store.StoreID,
+		Total:   len(occupants),
+		Zones:   make([]ZoneOccupancy, len(store.Zones)),
+	}
+	index := make(map[string]int, len(store.Zones))
+	for i, zone := range store.Zones {
+		occupancy.Zones[i] = ZoneOccupancy{Zone: zone.Name, Floor: zone.Floor}
+		index[zone.Name] = i
+	}
+	for _, occupant := range occupants {
+		zone, ok := store.ZoneOf(occupant.Location.Name())
+		if occupant.Location.IsZero() || !ok {
+			occupancy.Unzoned++
+			continue
+		}
+		occupancy.Zones[index[zone.Name]].Count++
+	}
+	return occupancy, nil
+}
+
+// findStore retrieves storeID.
+// Returns an ErrStoreNotFound error if the store is not registered.
+func (s *presenceService) findStore(ctx context.Context, storeID string) (*aggregates.Store, error) {
+	if storeID == "" {
+		return nil, domainerr.Errorf(domainerr.ErrInvalidArgument, "storeID is required")
+	}
+	store, err := s.stores.FindStore(ctx, storeID)
+	if err != nil {
+		return nil, domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve store %s: %w", storeID, err)
+	}
+	if store == nil {
+		return nil, domainerr.Errorf(domainerr.ErrStoreNotFound, "store %s not found", storeID)
+	}
+	return store, nil
+}
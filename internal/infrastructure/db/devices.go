@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// FindCustomerID retrieves the ID of the customer deviceID is bound to from PostgreSQL.
+// Returns an empty string if the device is not bound, or an error if the query fails.
+func (s *PostgresStorage) FindCustomerID(ctx context.Context, deviceID entities.DeviceID) (string, error) {
+	if err := deviceID.Validate(); err != nil {
+		return "", fmt.Errorf("invalid device ID: %w", err)
+	}
+
+	query := `
+		SELECT customer_id
+		FROM customer_devices
+		WHERE device_id = $1
+	`
+	var customerID string
+	err := s.pool.QueryRow(ctx, query, deviceID.String()).Scan(&customerID)
+	if err == pgx.ErrNoRows {
+		return "", nil // Not bound, not an error
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query customer by device %s: %w", deviceID, err)
+	}
+	return customerID, nil
+}
+
+// BindDevice saves customer and binds deviceID to it in a single transaction.
+// The binding is inserted with ON CONFLICT DO NOTHING, so when another request bound
+// the device first the transaction is rolled back, customer is discarded and the ID
+// of the existing customer is returned.
+// Returns an error if any statement fails.
+func (s *PostgresStorage) BindDevice(ctx context.Context, deviceID entities.DeviceID, customer *entities.Customer) (string, error) {
+	if err := deviceID.Validate(); err != nil {
+		return "", fmt.Errorf("invalid device ID: %w", err)
+	}
+	if customer == nil {
+		return "", fmt.Errorf("customer is required")
+	}
+	if err := customer.Validate(); err != nil {
+		return "", fmt.Errorf("invalid customer: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	if err = saveCustomer(ctx, tx, customer); err != nil {
+		return "", err
+	}
+
+	query := `
+		INSERT INTO customer_devices (device_id, customer_id)
+		VALUES ($1, $2)
+		ON CONFLICT (device_id) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, query, deviceID.String(), customer.CustomerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to bind device %s to customer %s: %w", deviceID, customer.CustomerID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		// Lost the race: drop the new customer and use the one bound first
+		if err = tx.Rollback(ctx); err != nil {
+			return "", fmt.Errorf("failed to roll back binding of device %s: %w", deviceID, err)
+		}
+		customerID, err := s.FindCustomerID(ctx, deviceID)
+		if err != nil {
+			return "", err
+		}
+		if customerID == "" {
+			return "", fmt.Errorf("device %s was bound concurrently but its binding is missing", deviceID)
+		}
+		return customerID, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit binding of device %s: %w", deviceID, err)
+	}
+	return customer.CustomerID, nil
+}
+
+// Verify interfaces are implemented
+var _ ports.CustomerDeviceRepository = (*PostgresStorage)(nil)
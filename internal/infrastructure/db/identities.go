@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+// PostgreSQL error codes of constraint violations mapped to domain errors.
+const (
+	uniqueViolation     = "23505" // Unique constraint violated
+	foreignKeyViolation = "23503" // Referenced row does not exist
+)
+
+// IdentityStorage implements IdentityRepository on the customer_identities table.
+// It shares the connection pool of the PostgresStorage it was obtained from; a separate
+// type is needed because PostgresStorage.Save already persists customers.
+type IdentityStorage struct {
+	pool *pgxpool.Pool // Connection pool shared with PostgresStorage
+}
+
+// Identities returns the identification history backed by this storage.
+func (s *PostgresStorage) Identities() *IdentityStorage {
+	return &IdentityStorage{pool: s.pool}
+}
+
+// Save inserts identity into customer_identities.
+// Returns an error if the identity is invalid or the insert fails; an identity of the same
+// customer detected at the same time is reported as domainerr.ErrDuplicate.
+func (s *IdentityStorage) Save(ctx context.Context, identity *aggregates.CustomerIdentity) error {
+	return insertIdentity(ctx, s.pool, identity)
+}
+
+// insertIdentity inserts identity into customer_identities using db.
+func insertIdentity(ctx context.Context, db execer, identity *aggregates.CustomerIdentity) error {
+	if identity == nil {
+		return fmt.Errorf("identity is required")
+	}
+	if err := identity.Validate(); err != nil {
+		return fmt.Errorf("invalid identity: %w", err)
+	}
+
+	var beaconID *string // NULL for QR identifications
+	if identity.BeaconID != "" {
+		beaconID = &identity.BeaconID
+	}
+
+	query := `
+		INSERT INTO customer_identities (customer_id, beacon_id, store_id, source, location, location_type, confidence, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	location, locationType := locationColumns(identity.Location)
+	_, err := db.Exec(ctx, query,
+		identity.CustomerID,
+		beaconID,
+		identity.StoreID,
+		string(identity.GetSource()),
+		location,
+		locationType,
+		identity.Confidence,
+		identity.DetectedAt,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domainerr.Errorf(domainerr.ErrDuplicate, "identity of customer %s at %v already recorded: %w", identity.CustomerID, identity.DetectedAt, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert identity for customer %s: %w", identity.CustomerID, err)
+	}
+	return nil
+}
+
+// ListByCustomer returns the identities of customerID detected within the range, most recent first.
+// Returns an empty slice if there are none, or an error if the query fails.
+func (s *IdentityStorage) ListByCustomer(ctx context.Context, customerID string, within ports.TimeRange, page ports.Page) ([]*aggregates.CustomerIdentity, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customerID is required")
+	}
+	return s.list(ctx, "customer_id", customerID, within, page)
+}
+
+// ListByBeacon returns the identities detected by beaconID within the range, most recent first.
+// Returns an empty slice if there are none, or an error if the query fails.
+func (s *IdentityStorage) ListByBeacon(ctx context.Context, beaconID string, within ports.TimeRange, page ports.Page) ([]*aggregates.CustomerIdentity, error) {
+	if beaconID == "" {
+		return nil, fmt.Errorf("beaconID is required")
+	}
+	return s.list(ctx, "beacon_id", beaconID, within, page)
+}
+
+// list queries identities whose column equals value. column is never user input.
+// Bounds on detected_at are only added when set, so that partitions outside the
+// range are pruned by the planner.
+func (s *IdentityStorage) list(ctx context.Context, column, value string, within ports.TimeRange, page ports.Page) ([]*aggregates.CustomerIdentity, error) {
+	if page.Limit <= 0 || page.Limit > ports.MaxPageSize {
+		return nil, fmt.Errorf("page limit must be between 1 and %d, got %d", ports.MaxPageSize, page.Limit)
+	}
+	if page.Offset < 0 {
+		return nil, fmt.Errorf("page offset must not be negative, got %d", page.Offset)
+	}
+	if !within.From.IsZero() && !within.To.IsZero() && !within.From.Before(within.To) {
+		return nil, fmt.Errorf("time range start %v must be before end %v", within.From, within.To)
+	}
+
+	conditions := []string{column + " = $1"}
+	args := []any{value}
+	if !within.From.IsZero() {
+		args = append(args, within.From)
+		conditions = append(conditions, fmt.Sprintf("detected_at >= $%d", len(args)))
+	}
+	if !within.To.IsZero() {
+		args = append(args, within.To)
+		conditions = append(conditions, fmt.Sprintf("detected_at < $%d", len(args)))
+	}
+	args = append(args, page.Limit, page.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT customer_id, beacon_id, store_id, source, location, location_type, confidence, detected_at
+		FROM customer_identities
+		WHERE %s
+		ORDER BY detected_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identities by %s %s: %w", column, value, err)
+	}
+	identities, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*aggregates.CustomerIdentity, error) {
+		var identity aggregates.CustomerIdentity
+		var beaconID, storeID, location, locationType *string
+		var source string
+		err := row.Scan(&identity.CustomerID, &beaconID, &storeID, &source, &location, &locationType, &identity.Confidence, &identity.DetectedAt)
+		if err != nil {
+			return nil, err
+		}
+		identity.Source = aggregates.Source(source)
+		if beaconID != nil {
+			identity.BeaconID = *beaconID
+		}
+		if storeID != nil {
+			identity.StoreID = *storeID
+		}
+		if identity.Location, err = scanLocation(location, locationType); err != nil {
+			return nil, fmt.Errorf("invalid location of identity of customer %s: %w", identity.CustomerID, err)
+		}
+		identity.DetectedAt = identity.DetectedAt.UTC()
+		return &identity, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan identities by %s %s: %w", column, value, err)
+	}
+	return identities, nil
+}
+
+// Verify interfaces are implemented
+var _ ports.IdentityRepository = (*IdentityStorage)(nil)
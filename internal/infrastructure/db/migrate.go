@@ -0,0 +1,324 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// migrationFiles holds the schema migrations compiled into the binary.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationName matches migration file names such as 0002_outbox.up.sql.
+var migrationName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change with the SQL to apply and revert it.
+type Migration struct {
+	Version int    // Sequential version, starting at 1
+	Name    string // Short description taken from the file name
+	Up      string // SQL applying the change
+	Down    string // SQL reverting the change
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time // Time the migration was applied, nil if pending
+}
+
+// Migrations returns the migrations embedded in the binary, ordered by version.
+func Migrations() ([]Migration, error) {
+	return ParseMigrations(migrationFiles, "migrations")
+}
+
+// ParseMigrations reads <version>_<name>.up.sql and <version>_<name>.down.sql pairs from dir.
+// Versions must be numbered 1, 2, 3, ... without gaps and every migration needs both files.
+// Returns the migrations ordered by version, or an error if the set is inconsistent.
+func ParseMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s does not match <version>_<name>.(up|down).sql", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file %s has an invalid version", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d has conflicting names %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be sequential from 1, found %d at position %d", m.Version, i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrator applies and reverts schema migrations, tracking them in schema_migrations.
+// Each migration runs in its own transaction together with its tracking row, under an
+// advisory lock, so that concurrently starting instances apply every migration once.
+type Migrator struct {
+	pool       *pgxpool.Pool // Connection pool shared with PostgresStorage
+	migrations []Migration   // Known migrations, ordered by version
+	logger     *zap.Logger   // Logger for applied and reverted migrations
+}
+
+// NewMigrator creates a new Migrator running the embedded migrations against storage.
+// Returns an error if dependencies are invalid or the embedded migrations are inconsistent.
+func NewMigrator(storage *PostgresStorage, logger *zap.Logger) (*Migrator, error) {
+	if storage == nil {
+		return nil, fmt.Errorf("postgres storage is required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		pool:       storage.pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Latest returns the highest known migration version.
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied migration, if any.
+func (m *Migrator) Down(ctx context.Context) error {
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return nil
+	}
+	return m.To(ctx, current-1)
+}
+
+// To applies or reverts migrations one at a time until the schema is at version.
+// Version 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version < 0 || version > m.Latest() {
+		return fmt.Errorf("migration version must be between 0 and %d, got %d", m.Latest(), version)
+	}
+	for {
+		done, err := m.step(ctx, version)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// Version returns the current schema version, 0 if no migration has been applied.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return currentVersion(applied), nil
+}
+
+// Status returns every known migration with the time it was applied, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if at, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// step moves the schema one migration towards target in a single transaction.
+// Returns true once the schema is at target.
+func (m *Migrator) step(ctx context.Context, target int) (bool, error) {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schema_migrations'))`); err != nil {
+		return false, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if err = ensureMigrationsTable(ctx, tx); err != nil {
+		return false, err
+	}
+	applied, err := queryApplied(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	current := currentVersion(applied)
+	if current > m.Latest() {
+		return false, fmt.Errorf("database schema version %d is newer than the latest known migration %d", current, m.Latest())
+	}
+
+	switch {
+	case current < target:
+		migration := m.migrations[current] // Versions are sequential from 1
+		if _, err = tx.Exec(ctx, migration.Up); err != nil {
+			return false, fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name); err != nil {
+			return false, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return false, fmt.Errorf("failed to commit migration %d: %w", migration.Version, err)
+		}
+		m.logger.Info("Applied migration", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+		return current+1 == target, nil
+
+	case current > target:
+		migration := m.migrations[current-1]
+		if _, err = tx.Exec(ctx, migration.Down); err != nil {
+			return false, fmt.Errorf("failed to revert migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err = tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+			return false, fmt.Errorf("failed to unrecord migration %d: %w", migration.Version, err)
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return false, fmt.Errorf("failed to commit revert of migration %d: %w", migration.Version, err)
+		}
+		m.logger.Info("Reverted migration", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+		return current-1 == target, nil
+
+	default:
+		return true, nil
+	}
+}
+
+// applied returns the applied migration versions and when they were applied.
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = ensureMigrationsTable(ctx, tx); err != nil {
+		return nil, err
+	}
+	applied, err := queryApplied(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit migration status: %w", err)
+	}
+	return applied, nil
+}
+
+// ensureMigrationsTable creates the schema_migrations tracking table if it does not exist.
+func ensureMigrationsTable(ctx context.Context, tx pgx.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY,
+			name VARCHAR(128) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// queryApplied reads the applied migration versions from schema_migrations.
+func queryApplied(ctx context.Context, tx pgx.Tx) (map[int]time.Time, error) {
+	rows, err := tx.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt.UTC()
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// currentVersion returns the highest applied version.
+// Migrations are applied and reverted strictly in order, so all lower versions are applied too.
+func currentVersion(applied map[int]time.Time) int {
+	current := 0
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS customer_identities;
+DROP TABLE IF EXISTS beacons;
+DROP TABLE IF EXISTS customers;
@@ -1,9 +1,8 @@
--- schema.sql defines the PostgreSQL schema for the TasteSync customer-id service.
--- It includes tables for customers, beacons, and customer identities with
--- appropriate constraints, indexes, and partitions for performance and scalability.
+-- Initial schema: customers, beacons and the partitioned customer identification history.
+-- IF NOT EXISTS lets databases created by the former schema.sql be adopted as version 1.
 
 -- Customers table stores customer entities with their unique IDs and preferences.
-CREATE TABLE customers (
+CREATE TABLE IF NOT EXISTS customers (
     customer_id VARCHAR(64) PRIMARY KEY,          -- Unique customer identifier (e.g., "cust123")
     last_seen TIMESTAMP WITH TIME ZONE NOT NULL,  -- Last identification timestamp (UTC)
     preferences JSONB DEFAULT '{}'::jsonb,        -- Customer preferences as JSON (e.g., {"drink": "coffee"})
@@ -11,10 +10,10 @@ CREATE TABLE customers (
 );
 
 -- Index on last_seen for efficient querying of recent customer activity.
-CREATE INDEX idx_customers_last_seen ON customers (last_seen);
+CREATE INDEX IF NOT EXISTS idx_customers_last_seen ON customers (last_seen);
 
 -- Beacons table stores beacon device entities with their unique IDs and metadata.
-CREATE TABLE beacons (
+CREATE TABLE IF NOT EXISTS beacons (
     beacon_id VARCHAR(36) PRIMARY KEY,            -- Unique beacon identifier (UUID, e.g., "550e8400-e29b-41d4-a716-446655440000")
     store_id VARCHAR(64) NOT NULL,                -- Store identifier (e.g., "store100")
     major INT NOT NULL CHECK (major >= 0 AND major <= 65535),  -- Major group (0-65535)
@@ -26,12 +25,12 @@ CREATE TABLE beacons (
 );
 
 -- Indexes for efficient querying by store_id and filtering by status.
-CREATE INDEX idx_beacons_store_id ON beacons (store_id);
-CREATE INDEX idx_beacons_status ON beacons (status);
+CREATE INDEX IF NOT EXISTS idx_beacons_store_id ON beacons (store_id);
+CREATE INDEX IF NOT EXISTS idx_beacons_status ON beacons (status);
 
 -- Customer_identities table stores customer identification events as an aggregate.
 -- Partitioned by detected_at for scalability with large datasets (e.g., 10M users).
-CREATE TABLE customer_identities (
+CREATE TABLE IF NOT EXISTS customer_identities (
     id BIGSERIAL,                                 -- Unique record ID (auto-incremented)
     customer_id VARCHAR(64) NOT NULL,             -- References customers(customer_id)
     beacon_id VARCHAR(36) NOT NULL,               -- References beacons(beacon_id)
@@ -43,13 +42,14 @@ CREATE TABLE customer_identities (
     FOREIGN KEY (beacon_id) REFERENCES beacons(beacon_id) ON DELETE RESTRICT
 ) PARTITION BY RANGE (detected_at);
 
--- Partition for 2025 data (extendable for future years).
-CREATE TABLE customer_identities_2025 PARTITION OF customer_identities
+-- Partition for 2025 data. Later partitions are created ahead of time by the
+-- partition manager (see postgres.partitions in config.yaml).
+CREATE TABLE IF NOT EXISTS customer_identities_2025 PARTITION OF customer_identities
     FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
 
 -- Indexes for efficient querying by customer_id and detected_at.
-CREATE INDEX idx_customer_identities_customer_id ON customer_identities (customer_id);
-CREATE INDEX idx_customer_identities_detected_at ON customer_identities (detected_at);
+CREATE INDEX IF NOT EXISTS idx_customer_identities_customer_id ON customer_identities (customer_id);
+CREATE INDEX IF NOT EXISTS idx_customer_identities_detected_at ON customer_identities (detected_at);
 
 -- Unique constraint to prevent duplicate identifications within a short time frame.
-CREATE UNIQUE INDEX idx_customer_identities_unique ON customer_identities (customer_id, detected_at);
\ No newline at end of file
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_identities_unique ON customer_identities (customer_id, detected_at);
@@ -0,0 +1,2 @@
+-- Index for listing the identification history of a beacon.
+CREATE INDEX IF NOT EXISTS idx_customer_identities_beacon_id ON customer_identities (beacon_id, detected_at);
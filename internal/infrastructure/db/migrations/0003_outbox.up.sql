@@ -0,0 +1,17 @@
+-- Outbox table stores domain events written in the same transaction as the state change
+-- that produced them. A relay worker publishes pending rows to Kafka and marks them sent,
+-- giving at-least-once delivery without losing or inventing events.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,                     -- Sequential ID, defines delivery order
+    event_id UUID NOT NULL UNIQUE,                -- Event identifier (envelope event_id)
+    event_type VARCHAR(64) NOT NULL,              -- Event name (e.g., "CustomerIdentified")
+    message_key VARCHAR(64) NOT NULL,             -- Kafka message key (e.g., customer_id)
+    payload JSONB NOT NULL,                       -- Event envelope as JSON
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- Enqueue timestamp
+    sent_at TIMESTAMP WITH TIME ZONE,             -- Delivery timestamp, NULL while pending
+    attempts INT NOT NULL DEFAULT 0,              -- Number of delivery attempts
+    last_error TEXT                               -- Error of the last failed delivery attempt
+);
+
+-- Partial index so the relay only scans pending rows.
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE sent_at IS NULL;
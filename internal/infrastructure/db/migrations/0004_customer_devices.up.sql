@@ -0,0 +1,12 @@
+-- Customer_devices table binds app installations to customers. The mobile app presents
+-- its device ID with every identification request; the first request from an unknown
+-- device creates an anonymous customer and binds the device to it.
+CREATE TABLE IF NOT EXISTS customer_devices (
+    device_id VARCHAR(128) PRIMARY KEY,           -- App installation identifier
+    customer_id VARCHAR(64) NOT NULL,             -- References customers(customer_id)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- Binding timestamp
+    FOREIGN KEY (customer_id) REFERENCES customers(customer_id) ON DELETE CASCADE
+);
+
+-- Index for listing the devices of a customer.
+CREATE INDEX IF NOT EXISTS idx_customer_devices_customer_id ON customer_devices (customer_id);
@@ -0,0 +1,6 @@
+-- QR identifications cannot be represented without a beacon and are removed.
+DELETE FROM customer_identities WHERE beacon_id IS NULL;
+ALTER TABLE customer_identities DROP CONSTRAINT IF EXISTS valid_source;
+ALTER TABLE customer_identities DROP COLUMN IF EXISTS source;
+ALTER TABLE customer_identities DROP COLUMN IF EXISTS store_id;
+ALTER TABLE customer_identities ALTER COLUMN beacon_id SET NOT NULL;
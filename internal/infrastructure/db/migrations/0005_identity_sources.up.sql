@@ -0,0 +1,16 @@
+-- Identities can come from beacons or from scanned QR codes. QR identifications have no
+-- beacon, so beacon_id becomes optional and the store is recorded on every identity.
+ALTER TABLE customer_identities ALTER COLUMN beacon_id DROP NOT NULL;
+ALTER TABLE customer_identities ADD COLUMN IF NOT EXISTS store_id VARCHAR(64);   -- Store of the beacon or QR code
+ALTER TABLE customer_identities ADD COLUMN IF NOT EXISTS source VARCHAR(16) NOT NULL DEFAULT 'beacon';  -- Identification channel (beacon, qr)
+
+-- Backfill the store of existing beacon identifications.
+UPDATE customer_identities
+SET store_id = beacons.store_id
+FROM beacons
+WHERE customer_identities.beacon_id = beacons.beacon_id AND customer_identities.store_id IS NULL;
+
+-- Beacon identifications must reference a beacon; QR identifications must not.
+ALTER TABLE customer_identities ADD CONSTRAINT valid_source CHECK (
+    (source = 'beacon' AND beacon_id IS NOT NULL) OR (source = 'qr' AND beacon_id IS NULL)
+);
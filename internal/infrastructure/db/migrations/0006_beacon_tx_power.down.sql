@@ -0,0 +1,2 @@
+ALTER TABLE beacons DROP CONSTRAINT IF EXISTS valid_tx_power;
+ALTER TABLE beacons DROP COLUMN IF EXISTS tx_power;
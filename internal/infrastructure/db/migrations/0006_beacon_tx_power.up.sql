@@ -0,0 +1,4 @@
+-- Calibrated RSSI measured 1 meter from each beacon, used to estimate the distance of
+-- detections. 0 means not calibrated, in which case the typical -59 dBm is assumed.
+ALTER TABLE beacons ADD COLUMN IF NOT EXISTS tx_power INT NOT NULL DEFAULT 0;
+ALTER TABLE beacons ADD CONSTRAINT valid_tx_power CHECK (tx_power >= -100 AND tx_power <= 0);
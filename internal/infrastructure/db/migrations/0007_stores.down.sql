@@ -0,0 +1,2 @@
+ALTER TABLE beacons DROP CONSTRAINT IF EXISTS fk_beacons_store;
+DROP TABLE IF EXISTS stores;
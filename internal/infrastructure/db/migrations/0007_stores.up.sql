@@ -0,0 +1,20 @@
+-- Stores table holds the venues beacons are installed in, with the local timezone,
+-- weekly opening hours and floor layout needed to relate identifications to local time
+-- and areas of the store.
+CREATE TABLE IF NOT EXISTS stores (
+    store_id VARCHAR(64) PRIMARY KEY,             -- Unique store identifier (e.g., "store100")
+    name VARCHAR(128) NOT NULL,                   -- Display name (e.g., "Gangnam Branch")
+    timezone VARCHAR(64) NOT NULL,                -- IANA timezone name (e.g., "Asia/Seoul")
+    opening_hours JSONB NOT NULL DEFAULT '[]'::jsonb,  -- [{"weekday": 1, "opens": 660, "closes": 1320}], minutes since local midnight
+    zones JSONB NOT NULL DEFAULT '[]'::jsonb,     -- [{"name": "Terrace", "floor": 0, "locations": ["Table 10"]}]
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP  -- Last update timestamp
+);
+
+-- Adopt the stores existing beacons refer to, named after their ID and in UTC until
+-- they are set up, so that every beacon references a real store.
+INSERT INTO stores (store_id, name, timezone)
+SELECT DISTINCT store_id, store_id, 'UTC' FROM beacons
+ON CONFLICT (store_id) DO NOTHING;
+
+ALTER TABLE beacons ADD CONSTRAINT fk_beacons_store
+    FOREIGN KEY (store_id) REFERENCES stores(store_id) ON DELETE RESTRICT;
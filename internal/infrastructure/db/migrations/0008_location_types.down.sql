@@ -0,0 +1,4 @@
+ALTER TABLE customer_identities DROP CONSTRAINT IF EXISTS valid_identity_location_type;
+ALTER TABLE customer_identities DROP COLUMN IF EXISTS location_type;
+ALTER TABLE beacons DROP CONSTRAINT IF EXISTS valid_location_type;
+ALTER TABLE beacons DROP COLUMN IF EXISTS location_type;
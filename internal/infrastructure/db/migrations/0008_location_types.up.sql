@@ -0,0 +1,21 @@
+-- Type of the location (entrance, table, counter) of beacons and identifications, so that
+-- consumers can tell an entrance detection from a table detection without parsing names.
+-- NULL while the location is unknown.
+ALTER TABLE beacons ADD COLUMN IF NOT EXISTS location_type VARCHAR(16);
+ALTER TABLE beacons ADD CONSTRAINT valid_location_type
+    CHECK (location_type IN ('entrance', 'table', 'counter'));
+
+-- Infer the type of existing beacons from their location names.
+UPDATE beacons
+SET location_type = CASE
+        WHEN location ILIKE '%entrance%' THEN 'entrance'
+        WHEN location ILIKE '%counter%' THEN 'counter'
+        ELSE 'table'
+    END
+WHERE location IS NOT NULL AND location <> '' AND location_type IS NULL;
+
+-- Identifications recorded before this migration keep a NULL type, which readers infer
+-- from the location name the same way, so that the history need not be rewritten.
+ALTER TABLE customer_identities ADD COLUMN IF NOT EXISTS location_type VARCHAR(16);
+ALTER TABLE customer_identities ADD CONSTRAINT valid_identity_location_type
+    CHECK (location_type IN ('entrance', 'table', 'counter'));
@@ -0,0 +1,21 @@
+-- Visits table holds customers' visits to stores: the stays at each location from the
+-- first identification at a store until the customer has not been identified for the
+-- visit timeout of the store's identification policy.
+CREATE TABLE IF NOT EXISTS visits (
+    visit_id VARCHAR(36) PRIMARY KEY,             -- Unique visit identifier (UUID)
+    customer_id VARCHAR(64) NOT NULL REFERENCES customers(customer_id),
+    store_id VARCHAR(64) NOT NULL,                -- Store visited; not a foreign key, as QR codes may name stores without beacons
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL, -- First identification of the visit
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,  -- Latest identification of the visit
+    ended_at TIMESTAMP WITH TIME ZONE,            -- Equal to last_seen_at once ended; NULL while open
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- last_seen_at plus the visit timeout
+    stays JSONB NOT NULL,                         -- [{"location": {"name": "Table 5", "type": "table"}, "since": ..., "until": ...}]
+    version INT NOT NULL DEFAULT 1,               -- Incremented on every update, for optimistic locking
+    CONSTRAINT valid_visit_period CHECK (last_seen_at >= started_at AND expires_at > last_seen_at)
+);
+
+-- A customer is on at most one visit at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_visits_open_customer_id ON visits(customer_id) WHERE ended_at IS NULL;
+-- Open visits are swept in order of expiry.
+CREATE INDEX IF NOT EXISTS idx_visits_open_expires_at ON visits(expires_at) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_visits_store_id_started_at ON visits(store_id, started_at);
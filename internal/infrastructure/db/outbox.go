@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+)
+
+// RecordIdentification saves customer, inserts identity into customer_identities, saves
+// the visits changed by the identification and enqueues event and the visit events in the
+// outbox within a single transaction.
+// Returns an error if any statement fails, in which case the transaction is rolled back.
+func (s *PostgresStorage) RecordIdentification(ctx context.Context, customer *entities.Customer, identity *aggregates.CustomerIdentity, event events.CustomerIdentified, visits ...ports.VisitUpdate) error {
+	if customer == nil {
+		return fmt.Errorf("customer is required")
+	}
+	if err := customer.Validate(); err != nil {
+		return fmt.Errorf("invalid customer: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	if err = saveCustomer(ctx, tx, customer); err != nil {
+		return err
+	}
+
+	if err = insertIdentity(ctx, tx, identity); err != nil {
+		return err
+	}
+
+	if err = enqueueEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	for _, update := range visits {
+		if err = saveVisit(ctx, tx, update.Visit); err != nil {
+			return err
+		}
+		for _, visitEvent := range update.Events {
+			if err = enqueueEvent(ctx, tx, visitEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit identification of customer %s: %w", identity.CustomerID, err)
+	}
+	return nil
+}
+
+// enqueueEvent inserts event into the outbox using db.
+func enqueueEvent(ctx context.Context, db execer, event events.Event) error {
+	header := event.Header()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", header.EventType, err)
+	}
+
+	query := `
+		INSERT INTO outbox (event_id, event_type, message_key, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = db.Exec(ctx, query, header.EventID, header.EventType, event.Key(), payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s event %s: %w", header.EventType, header.EventID, err)
+	}
+	return nil
+}
+
+// RelayOutbox locks up to limit pending outbox rows with FOR UPDATE SKIP LOCKED, passes
+// them to deliver in ID order and marks delivered rows sent, all in one transaction.
+// A failed delivery is recorded on its row and stops the batch; rows that were already
+// delivered stay marked sent. If the commit itself fails, the rows remain pending and are
+// delivered again, which is what makes delivery at-least-once.
+// Returns the number of rows marked sent.
+func (s *PostgresStorage) RelayOutbox(ctx context.Context, limit int, deliver func(ctx context.Context, msg ports.OutboxMessage) error) (int, error) {
+	if limit <= 0 {
+		return 0, fmt.Errorf("limit must be positive")
+	}
+	if deliver == nil {
+		return 0, fmt.Errorf("deliver function is required")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	query := `
+		SELECT id, event_id, event_type, message_key, payload, created_at, attempts
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query pending outbox messages: %w", err)
+	}
+	messages, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (ports.OutboxMessage, error) {
+		var msg ports.OutboxMessage
+		err := row.Scan(&msg.ID, &msg.EventID, &msg.EventType, &msg.Key, &msg.Payload, &msg.CreatedAt, &msg.Attempts)
+		return msg, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan pending outbox messages: %w", err)
+	}
+
+	sent := 0
+	var deliverErr error
+	for _, msg := range messages {
+		if deliverErr = deliver(ctx, msg); deliverErr != nil {
+			_, err = tx.Exec(ctx, `UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`, msg.ID, deliverErr.Error())
+			if err != nil {
+				return 0, fmt.Errorf("failed to record delivery failure of outbox message %d: %w", msg.ID, err)
+			}
+			deliverErr = fmt.Errorf("failed to deliver outbox message %d (event %s): %w", msg.ID, msg.EventID, deliverErr)
+			break
+		}
+		_, err = tx.Exec(ctx, `UPDATE outbox SET attempts = attempts + 1, sent_at = CURRENT_TIMESTAMP WHERE id = $1`, msg.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark outbox message %d sent: %w", msg.ID, err)
+		}
+		sent++
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox relay: %w", err)
+	}
+	return sent, deliverErr
+}
+
+// Verify interfaces are implemented
+var _ ports.IdentificationRecorder = (*PostgresStorage)(nil)
+var _ ports.OutboxRepository = (*PostgresStorage)(nil)
@@ -0,0 +1,331 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/customer-id.git/internal/config"
+	"go.uber.org/zap"
+)
+
+// IdentitiesTable is the range-partitioned table holding the identification history.
+const IdentitiesTable = "customer_identities"
+
+// Granularity is the time span covered by a single partition.
+type Granularity string
+
+const (
+	GranularityDaily   Granularity = "daily"   // One partition per day, named <table>_YYYY_MM_DD
+	GranularityMonthly Granularity = "monthly" // One partition per month, named <table>_YYYY_MM
+	GranularityYearly  Granularity = "yearly"  // One partition per year, named <table>_YYYY
+)
+
+// nameLayouts maps each granularity to the time layout of its partition name suffix.
+var nameLayouts = map[Granularity]string{
+	GranularityDaily:   "2006_01_02",
+	GranularityMonthly: "2006_01",
+	GranularityYearly:  "2006",
+}
+
+// ParseGranularity converts a configuration value into a Granularity.
+// Returns an error if the value is not daily, monthly or yearly.
+func ParseGranularity(value string) (Granularity, error) {
+	g := Granularity(value)
+	if _, ok := nameLayouts[g]; !ok {
+		return "", fmt.Errorf("partition granularity must be daily, monthly or yearly, got %q", value)
+	}
+	return g, nil
+}
+
+// start returns the beginning of the period containing t, in UTC.
+func (g Granularity) start(t time.Time) time.Time {
+	t = t.UTC()
+	switch g {
+	case GranularityDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case GranularityYearly:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// next returns the beginning of the period following the one starting at start.
+func (g Granularity) next(start time.Time) time.Time {
+	switch g {
+	case GranularityDaily:
+		return start.AddDate(0, 0, 1)
+	case GranularityYearly:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// PartitionRange is a partition of a range-partitioned table and the [From, To) range it covers.
+type PartitionRange struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// PartitionFor returns the partition of parent holding rows detected at t.
+func PartitionFor(parent string, g Granularity, t time.Time) PartitionRange {
+	from := g.start(t)
+	return PartitionRange{
+		Name: parent + "_" + from.Format(nameLayouts[g]),
+		From: from,
+		To:   g.next(from),
+	}
+}
+
+// ParsePartition derives the range of a partition of parent from its name.
+// Names of every granularity are recognised, so partitions created under a previous
+// setting (e.g. the yearly customer_identities_2025) keep being managed.
+// Returns false if name does not follow the naming scheme.
+func ParsePartition(parent, name string) (PartitionRange, bool) {
+	suffix, ok := strings.CutPrefix(name, parent+"_")
+	if !ok {
+		return PartitionRange{}, false
+	}
+	for g, layout := range nameLayouts {
+		if len(suffix) != len(layout) {
+			continue
+		}
+		from, err := time.Parse(layout, suffix)
+		if err != nil {
+			continue
+		}
+		return PartitionRange{Name: name, From: from, To: g.next(from)}, true
+	}
+	return PartitionRange{}, false
+}
+
+// PartitionPolicy controls which partitions are kept.
+type PartitionPolicy struct {
+	Granularity Granularity   // Span of newly created partitions
+	Premake     int           // Number of future partitions kept ahead of the current one
+	Retention   time.Duration // Age after which partitions expire; zero keeps them forever
+}
+
+// PlanPartitions compares the existing partitions of parent with policy at now.
+// It returns the partitions to create so that the current period and Premake periods
+// ahead are covered, and the existing partitions whose whole range is older than the
+// retention window, oldest first. A period already covered in part by existing partitions,
+// e.g. daily ones after switching to monthly, is completed with partitions for the ranges
+// they leave uncovered rather than skipped. Names that do not follow the naming scheme are
+// neither counted nor expired.
+func PlanPartitions(parent string, existing []string, now time.Time, policy PartitionPolicy) (create, expire []PartitionRange) {
+	var current []PartitionRange
+	for _, name := range existing {
+		if p, ok := ParsePartition(parent, name); ok {
+			current = append(current, p)
+		}
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].From.Before(current[j].From) })
+
+	t := now
+	for i := 0; i <= policy.Premake; i++ {
+		p := PartitionFor(parent, policy.Granularity, t)
+		create = append(create, uncovered(parent, p, current)...)
+		t = p.To
+	}
+
+	if policy.Retention > 0 {
+		cutoff := now.UTC().Add(-policy.Retention)
+		for _, c := range current {
+			if !c.To.After(cutoff) {
+				expire = append(expire, c)
+			}
+		}
+	}
+	return create, expire
+}
+
+// uncovered returns the partitions of parent to create for the parts of p that are not
+// covered by current, sorted by From: p itself if nothing overlaps it, otherwise the
+// partitions filling the gaps between the overlapping ones.
+func uncovered(parent string, p PartitionRange, current []PartitionRange) []PartitionRange {
+	var gaps []PartitionRange
+	from := p.From
+	for _, c := range current {
+		if !c.From.Before(p.To) || !from.Before(c.To) {
+			continue
+		}
+		if from.Before(c.From) {
+			gaps = append(gaps, fill(parent, from, c.From)...)
+		}
+		from = c.To
+	}
+	if from.Equal(p.From) {
+		return []PartitionRange{p}
+	}
+	if from.Before(p.To) {
+		gaps = append(gaps, fill(parent, from, p.To)...)
+	}
+	return gaps
+}
+
+// fill returns the partitions of parent covering [from, to), using the coarsest
+// granularity whose period fits at each step. Both bounds are period boundaries of the
+// daily granularity, as the ranges of all partitions are.
+func fill(parent string, from, to time.Time) []PartitionRange {
+	var partitions []PartitionRange
+	for from.Before(to) {
+		p := PartitionFor(parent, GranularityDaily, from)
+		for _, g := range []Granularity{GranularityYearly, GranularityMonthly} {
+			if coarser := PartitionFor(parent, g, from); coarser.From.Equal(from) && !coarser.To.After(to) {
+				p = coarser
+				break
+			}
+		}
+		partitions = append(partitions, p)
+		from = p.To
+	}
+	return partitions
+}
+
+// PartitionManager keeps the partitions of customer_identities in line with a PartitionPolicy.
+// It creates partitions ahead of time so that inserts never hit a missing range, and
+// detaches (and optionally drops) partitions that fall out of the retention window.
+type PartitionManager struct {
+	pool          *pgxpool.Pool   // Connection pool shared with PostgresStorage
+	policy        PartitionPolicy // Partitions to keep
+	dropExpired   bool            // Drop expired partitions after detaching them
+	checkInterval time.Duration   // Delay between maintenance runs
+	logger        *zap.Logger     // Logger for partition changes
+}
+
+// NewPartitionManager creates a new PartitionManager for the tables of storage.
+// Returns an error if the configuration or dependencies are invalid.
+func NewPartitionManager(storage *PostgresStorage, cfg config.PartitionConfig, logger *zap.Logger) (*PartitionManager, error) {
+	if storage == nil {
+		return nil, fmt.Errorf("postgres storage is required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	granularity, err := ParseGranularity(cfg.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Premake < 0 {
+		return nil, fmt.Errorf("partition premake must not be negative, got %d", cfg.Premake)
+	}
+	if cfg.Retention < 0 {
+		return nil, fmt.Errorf("partition retention must not be negative, got %v", cfg.Retention)
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("partition check interval must be positive")
+	}
+	return &PartitionManager{
+		pool: storage.pool,
+		policy: PartitionPolicy{
+			Granularity: granularity,
+			Premake:     cfg.Premake,
+			Retention:   cfg.Retention,
+		},
+		dropExpired:   cfg.DropExpired,
+		checkInterval: cfg.CheckInterval,
+		logger:        logger,
+	}, nil
+}
+
+// Run maintains partitions every check interval until ctx is cancelled.
+// Failures are logged and retried on the next run.
+func (m *PartitionManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Maintain(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				m.logger.Error("Partition maintenance failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Maintain creates missing partitions and expires old ones as of now.
+// Changes are made in one transaction under an advisory lock, so concurrent
+// instances do not race on the same DDL.
+// Returns an error if any statement fails, in which case nothing is changed.
+func (m *PartitionManager) Maintain(ctx context.Context, now time.Time) error {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, IdentitiesTable+" partitions"); err != nil {
+		return fmt.Errorf("failed to acquire partition lock: %w", err)
+	}
+
+	existing, err := listPartitions(ctx, tx, IdentitiesTable)
+	if err != nil {
+		return err
+	}
+	create, expire := PlanPartitions(IdentitiesTable, existing, now, m.policy)
+
+	parent := pgx.Identifier{IdentitiesTable}.Sanitize()
+	for _, p := range create {
+		query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')`,
+			pgx.Identifier{p.Name}.Sanitize(), parent,
+			p.From.Format(time.RFC3339), p.To.Format(time.RFC3339))
+		if _, err = tx.Exec(ctx, query); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", p.Name, err)
+		}
+	}
+	for _, p := range expire {
+		if _, err = tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, parent, pgx.Identifier{p.Name}.Sanitize())); err != nil {
+			return fmt.Errorf("failed to detach partition %s: %w", p.Name, err)
+		}
+		if m.dropExpired {
+			if _, err = tx.Exec(ctx, fmt.Sprintf(`DROP TABLE %s`, pgx.Identifier{p.Name}.Sanitize())); err != nil {
+				return fmt.Errorf("failed to drop partition %s: %w", p.Name, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit partition maintenance: %w", err)
+	}
+
+	for _, p := range create {
+		m.logger.Info("Created partition", zap.String("partition", p.Name),
+			zap.Time("from", p.From), zap.Time("to", p.To))
+	}
+	for _, p := range expire {
+		m.logger.Info("Expired partition", zap.String("partition", p.Name),
+			zap.Time("to", p.To), zap.Bool("dropped", m.dropExpired))
+	}
+	return nil
+}
+
+// listPartitions returns the names of the partitions currently attached to parent.
+func listPartitions(ctx context.Context, tx pgx.Tx, parent string) ([]string, error) {
+	query := `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = $1
+	`
+	rows, err := tx.Query(ctx, query, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", parent, err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan partitions of %s: %w", parent, err)
+	}
+	return names, nil
+}
@@ -3,12 +3,15 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
 )
 
@@ -84,6 +87,18 @@ func (s *PostgresStorage) Save(ctx context.Context, customer *entities.Customer)
 		return fmt.Errorf("invalid customer: %w", err)
 	}
 
+	return saveCustomer(ctx, s.pool, customer)
+}
+
+// execer is implemented by both the connection pool and transactions, so that
+// statements can be shared between standalone and transactional writes.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// saveCustomer upserts customer using db. last_seen never moves back, so that a request
+// carrying a late reading cannot overwrite a newer sighting saved concurrently.
+func saveCustomer(ctx context.Context, db execer, customer *entities.Customer) error {
 	// Marshal preferences to JSON
 	preferencesJSON, err := json.Marshal(customer.Preferences)
 	if err != nil {
@@ -94,9 +109,9 @@ func (s *PostgresStorage) Save(ctx context.Context, customer *entities.Customer)
 		INSERT INTO customers (customer_id, last_seen, preferences)
 		VALUES ($1, $2, $3)
 		ON CONFLICT (customer_id)
-		DO UPDATE SET last_seen = EXCLUDED.last_seen, preferences = EXCLUDED.preferences
+		DO UPDATE SET last_seen = GREATEST(customers.last_seen, EXCLUDED.last_seen), preferences = EXCLUDED.preferences
 	`
-	_, err = s.pool.Exec(ctx, query, customer.CustomerID, customer.LastSeen, preferencesJSON)
+	_, err = db.Exec(ctx, query, customer.CustomerID, customer.LastSeen, preferencesJSON)
 	if err != nil {
 		return fmt.Errorf("failed to save customer %s: %w", customer.CustomerID, err)
 	}
@@ -106,7 +121,8 @@ func (s *PostgresStorage) Save(ctx context.Context, customer *entities.Customer)
 
 // SaveBeacon persists a beacon entity to PostgreSQL.
 // It inserts or updates the record based on the beacon_id using ON CONFLICT.
-// Returns an error if the operation fails.
+// Returns an error if the operation fails; a beacon of a store that does not exist is
+// reported as domainerr.ErrInvalidArgument.
 func (s *PostgresStorage) SaveBeacon(ctx context.Context, beacon *entities.Beacon) error {
 	if beacon == nil {
 		return fmt.Errorf("beacon is required")
@@ -116,21 +132,29 @@ func (s *PostgresStorage) SaveBeacon(ctx context.Context, beacon *entities.Beaco
 	}
 
 	query := `
-		INSERT INTO beacons (beacon_id, store_id, major, minor, location, status, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO beacons (beacon_id, store_id, major, minor, location, location_type, status, tx_power, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (beacon_id)
 		DO UPDATE SET store_id = EXCLUDED.store_id, major = EXCLUDED.major, minor = EXCLUDED.minor, 
-		              location = EXCLUDED.location, status = EXCLUDED.status, updated_at = EXCLUDED.updated_at
+		              location = EXCLUDED.location, location_type = EXCLUDED.location_type, status = EXCLUDED.status,
+		              tx_power = EXCLUDED.tx_power, updated_at = EXCLUDED.updated_at
 	`
+	location, locationType := locationColumns(beacon.Location)
 	_, err := s.pool.Exec(ctx, query,
 		beacon.BeaconID,
 		beacon.StoreID,
 		beacon.Major,
 		beacon.Minor,
-		beacon.Location,
+		location,
+		locationType,
 		beacon.Status,
+		beacon.TxPower,
 		time.Now().UTC(),
 	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domainerr.Errorf(domainerr.ErrInvalidArgument, "store %s of beacon %s does not exist: %w", beacon.StoreID, beacon.BeaconID, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to save beacon %s: %w", beacon.BeaconID, err)
 	}
@@ -146,19 +170,11 @@ func (s *PostgresStorage) FindByUUID(ctx context.Context, uuid string) (*entitie
 	}
 
 	query := `
-		SELECT beacon_id, store_id, major, minor, location, status
+		SELECT beacon_id, store_id, major, minor, location, location_type, status, tx_power
 		FROM beacons
 		WHERE beacon_id = $1
 	`
-	var beacon entities.Beacon
-	err := s.pool.QueryRow(ctx, query, uuid).Scan(
-		&beacon.BeaconID,
-		&beacon.StoreID,
-		&beacon.Major,
-		&beacon.Minor,
-		&beacon.Location,
-		&beacon.Status,
-	)
+	beacon, err := scanBeacon(s.pool.QueryRow(ctx, query, uuid))
 	if err == pgx.ErrNoRows {
 		return nil, nil // Not found, not an error
 	}
@@ -166,9 +182,47 @@ func (s *PostgresStorage) FindByUUID(ctx context.Context, uuid string) (*entitie
 		return nil, fmt.Errorf("failed to query beacon by UUID %s: %w", uuid, err)
 	}
 
+	return beacon, nil
+}
+
+// scanBeacon scans a beacon selected as beacon_id, store_id, major, minor, location,
+// location_type, status, tx_power.
+func scanBeacon(row pgx.Row) (*entities.Beacon, error) {
+	var beacon entities.Beacon
+	var location, locationType *string
+	err := row.Scan(&beacon.BeaconID, &beacon.StoreID, &beacon.Major, &beacon.Minor, &location, &locationType, &beacon.Status, &beacon.TxPower)
+	if err != nil {
+		return nil, err
+	}
+	if beacon.Location, err = scanLocation(location, locationType); err != nil {
+		return nil, fmt.Errorf("invalid location of beacon %s: %w", beacon.BeaconID, err)
+	}
 	return &beacon, nil
 }
 
+// locationColumns returns the values of the location and location_type columns storing
+// location; both are NULL if the location is unknown.
+func locationColumns(location entities.Location) (*string, *string) {
+	if location.IsZero() {
+		return nil, nil
+	}
+	name, locationType := location.Name(), string(location.Type())
+	return &name, &locationType
+}
+
+// scanLocation returns the location stored in the location and location_type columns.
+// Rows written before locations had a type have a NULL type, which is inferred from the
+// name with entities.LegacyLocation.
+func scanLocation(name, locationType *string) (entities.Location, error) {
+	if name == nil || *name == "" {
+		return entities.Location{}, nil
+	}
+	if locationType == nil {
+		return entities.LegacyLocation(*name), nil
+	}
+	return entities.NewLocation(*name, entities.LocationType(*locationType))
+}
+
 // Close terminates the PostgreSQL connection pool.
 // It should be called when the storage is no longer needed to free resources.
 // Returns an error if closing fails.
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// openingHoursRow is the JSON form of aggregates.OpeningHours in stores.opening_hours.
+type openingHoursRow struct {
+	Weekday int `json:"weekday"` // 0 (Sunday) to 6 (Saturday)
+	Opens   int `json:"opens"`   // Minutes since local midnight
+	Closes  int `json:"closes"`  // Minutes since local midnight
+}
+
+// zoneRow is the JSON form of aggregates.Zone in stores.zones.
+type zoneRow struct {
+	Name      string   `json:"name"`
+	Floor     int      `json:"floor"`
+	Locations []string `json:"locations"`
+}
+
+// FindStore retrieves a store by its unique identifier from PostgreSQL.
+// Returns nil if not found, or an error if the query fails.
+func (s *PostgresStorage) FindStore(ctx context.Context, storeID string) (*aggregates.Store, error) {
+	if storeID == "" {
+		return nil, fmt.Errorf("storeID is required")
+	}
+
+	query := `
+		SELECT store_id, name, timezone, opening_hours, zones
+		FROM stores
+		WHERE store_id = $1
+	`
+	var store aggregates.Store
+	var timezone string
+	var hoursJSON, zonesJSON []byte
+	err := s.pool.QueryRow(ctx, query, storeID).Scan(&store.StoreID, &store.Name, &timezone, &hoursJSON, &zonesJSON)
+	if err == pgx.ErrNoRows {
+		return nil, nil // Not found, not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query store by ID %s: %w", storeID, err)
+	}
+
+	if store.Timezone, err = time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s of store %s: %w", timezone, storeID, err)
+	}
+	var hours []openingHoursRow
+	if err = json.Unmarshal(hoursJSON, &hours); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal opening hours of store %s: %w", storeID, err)
+	}
+	for _, h := range hours {
+		store.OpeningHours = append(store.OpeningHours, aggregates.OpeningHours{
+			Weekday: time.Weekday(h.Weekday),
+			Opens:   time.Duration(h.Opens) * time.Minute,
+			Closes:  time.Duration(h.Closes) * time.Minute,
+		})
+	}
+	var zones []zoneRow
+	if err = json.Unmarshal(zonesJSON, &zones); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal zones of store %s: %w", storeID, err)
+	}
+	for _, z := range zones {
+		store.Zones = append(store.Zones, aggregates.Zone{Name: z.Name, Floor: z.Floor, Locations: z.Locations})
+	}
+
+	return &store, nil
+}
+
+// SaveStore persists a store to PostgreSQL.
+// It inserts or updates the record based on the store_id using ON CONFLICT; opening hours
+// are stored with minute precision.
+// Returns an error if the operation fails.
+func (s *PostgresStorage) SaveStore(ctx context.Context, store *aggregates.Store) error {
+	if store == nil {
+		return fmt.Errorf("store is required")
+	}
+	if err := store.Validate(); err != nil {
+		return fmt.Errorf("invalid store: %w", err)
+	}
+
+	hours := make([]openingHoursRow, 0, len(store.OpeningHours))
+	for _, h := range store.OpeningHours {
+		hours = append(hours, openingHoursRow{
+			Weekday: int(h.Weekday),
+			Opens:   int(h.Opens / time.Minute),
+			Closes:  int(h.Closes / time.Minute),
+		})
+	}
+	hoursJSON, err := json.Marshal(hours)
+	if err != nil {
+		return fmt.Errorf("failed to marshal opening hours of store %s: %w", store.StoreID, err)
+	}
+	zones := make([]zoneRow, 0, len(store.Zones))
+	for _, z := range store.Zones {
+		zones = append(zones, zoneRow{Name: z.Name, Floor: z.Floor, Locations: z.Locations})
+	}
+	zonesJSON, err := json.Marshal(zones)
+	if err != nil {
+		return fmt.Errorf("failed to marshal zones of store %s: %w", store.StoreID, err)
+	}
+
+	query := `
+		INSERT INTO stores (store_id, name, timezone, opening_hours, zones, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (store_id)
+		DO UPDATE SET name = EXCLUDED.name, timezone = EXCLUDED.timezone, opening_hours = EXCLUDED.opening_hours,
+		              zones = EXCLUDED.zones, updated_at = EXCLUDED.updated_at
+	`
+	_, err = s.pool.Exec(ctx, query,
+		store.StoreID,
+		store.Name,
+		store.Timezone.String(),
+		hoursJSON,
+		zonesJSON,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save store %s: %w", store.StoreID, err)
+	}
+
+	return nil
+}
+
+// ListBeacons returns the beacons installed in storeID from PostgreSQL, ordered by beacon ID.
+// Returns an empty slice if there are none, or an error if the query fails.
+func (s *PostgresStorage) ListBeacons(ctx context.Context, storeID string) ([]*entities.Beacon, error) {
+	if storeID == "" {
+		return nil, fmt.Errorf("storeID is required")
+	}
+
+	query := `
+		SELECT beacon_id, store_id, major, minor, location, location_type, status, tx_power
+		FROM beacons
+		WHERE store_id = $1
+		ORDER BY beacon_id
+	`
+	rows, err := s.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query beacons of store %s: %w", storeID, err)
+	}
+	beacons, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Beacon, error) {
+		return scanBeacon(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan beacons of store %s: %w", storeID, err)
+	}
+	return beacons, nil
+}
+
+// Verify interfaces are implemented
+var _ ports.StoreRepository = (*PostgresStorage)(nil)
@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+)
+
+// stayRow is the JSON form of aggregates.Stay in visits.stays.
+type stayRow struct {
+	Location entities.Location `json:"location"` // {"name": ..., "type": ...}, or null if unknown
+	Since    time.Time         `json:"since"`
+	Until    time.Time         `json:"until"`
+}
+
+// visitColumns lists the columns read by scanVisit, in order.
+const visitColumns = `visit_id, customer_id, store_id, started_at, last_seen_at, ended_at, expires_at, stays, version`
+
+// FindOpenVisit retrieves the visit customerID is on from PostgreSQL.
+// Returns nil if the customer is not on a visit, or an error if the query fails.
+func (s *PostgresStorage) FindOpenVisit(ctx context.Context, customerID string) (*aggregates.Visit, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customerID is required")
+	}
+
+	query := `SELECT ` + visitColumns + `
+		FROM visits
+		WHERE customer_id = $1 AND ended_at IS NULL
+	`
+	visit, err := scanVisit(s.pool.QueryRow(ctx, query, customerID))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Not on a visit, not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open visit of customer %s: %w", customerID, err)
+	}
+	return visit, nil
+}
+
+// EndExpiredVisits locks up to limit open visits whose expires_at lies at or before now
+// with FOR UPDATE SKIP LOCKED, ends each with end and saves it together with its event in
+// the outbox, all in one transaction.
+// Returns the number of visits ended; if end or a statement fails, nothing is saved.
+func (s *PostgresStorage) EndExpiredVisits(ctx context.Context, now time.Time, limit int, end func(visit *aggregates.Visit) (events.Event, error)) (int, error) {
+	if limit <= 0 {
+		return 0, fmt.Errorf("limit must be positive")
+	}
+	if end == nil {
+		return 0, fmt.Errorf("end function is required")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op after a successful commit
+
+	query := `SELECT ` + visitColumns + `
+		FROM visits
+		WHERE ended_at IS NULL AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query expired visits: %w", err)
+	}
+	visits, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*aggregates.Visit, error) {
+		return scanVisit(row)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan expired visits: %w", err)
+	}
+
+	for _, visit := range visits {
+		event, err := end(visit)
+		if err != nil {
+			return 0, fmt.Errorf("failed to end visit %s: %w", visit.VisitID, err)
+		}
+		if err = saveVisit(ctx, tx, visit); err != nil {
+			return 0, err
+		}
+		if err = enqueueEvent(ctx, tx, event); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit ended visits: %w", err)
+	}
+	return len(visits), nil
+}
+
+// saveVisit inserts visit using db if it has never been saved, and updates it otherwise.
+// Updates only apply to the version that was read, so that a visit changed concurrently,
+// e.g. ended by the sweeper while an identification extended it, is not overwritten.
+// On success the visit's Version is advanced.
+func saveVisit(ctx context.Context, db execer, visit *aggregates.Visit) error {
+	if visit == nil {
+		return fmt.Errorf("visit is required")
+	}
+	if err := visit.Validate(); err != nil {
+		return fmt.Errorf("invalid visit: %w", err)
+	}
+
+	stays := make([]stayRow, 0, len(visit.Stays))
+	for _, stay := range visit.Stays {
+		stays = append(stays, stayRow{Location: stay.Location, Since: stay.Since, Until: stay.Until})
+	}
+	staysJSON, err := json.Marshal(stays)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stays of visit %s: %w", visit.VisitID, err)
+	}
+	var endedAt *time.Time // NULL while the visit is open
+	if !visit.IsOpen() {
+		endedAt = &visit.EndedAt
+	}
+
+	if visit.Version == 0 {
+		query := `
+			INSERT INTO visits (visit_id, customer_id, store_id, started_at, last_seen_at, ended_at, expires_at, stays, version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)
+		`
+		_, err = db.Exec(ctx, query,
+			visit.VisitID,
+			visit.CustomerID,
+			visit.StoreID,
+			visit.StartedAt,
+			visit.LastSeenAt,
+			endedAt,
+			visit.ExpiresAt(),
+			staysJSON,
+		)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("customer %s is already on another visit: %w", visit.CustomerID, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert visit %s: %w", visit.VisitID, err)
+		}
+		visit.Version = 1
+		return nil
+	}
+
+	query := `
+		UPDATE visits
+		SET last_seen_at = $3, ended_at = $4, expires_at = $5, stays = $6, version = version + 1
+		WHERE visit_id = $1 AND version = $2
+	`
+	tag, err := db.Exec(ctx, query, visit.VisitID, visit.Version, visit.LastSeenAt, endedAt, visit.ExpiresAt(), staysJSON)
+	if err != nil {
+		return fmt.Errorf("failed to update visit %s: %w", visit.VisitID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("visit %s was modified concurrently", visit.VisitID)
+	}
+	visit.Version++
+	return nil
+}
+
+// scanVisit scans a row of visitColumns into a Visit. The inactivity timeout is the time
+// between the latest identification and expiry.
+func scanVisit(row pgx.Row) (*aggregates.Visit, error) {
+	var visit aggregates.Visit
+	var endedAt *time.Time
+	var expiresAt time.Time
+	var staysJSON []byte
+	err := row.Scan(&visit.VisitID, &visit.CustomerID, &visit.StoreID, &visit.StartedAt, &visit.LastSeenAt, &endedAt, &expiresAt, &staysJSON, &visit.Version)
+	if err != nil {
+		return nil, err
+	}
+	visit.StartedAt = visit.StartedAt.UTC()
+	visit.LastSeenAt = visit.LastSeenAt.UTC()
+	if endedAt != nil {
+		visit.EndedAt = endedAt.UTC()
+	}
+	visit.Timeout = expiresAt.Sub(visit.LastSeenAt)
+
+	var stays []stayRow
+	if err = json.Unmarshal(staysJSON, &stays); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stays of visit %s: %w", visit.VisitID, err)
+	}
+	for _, stay := range stays {
+		visit.Stays = append(visit.Stays, aggregates.Stay{Location: stay.Location, Since: stay.Since.UTC(), Until: stay.Until.UTC()})
+	}
+	return &visit, nil
+}
+
+// Verify interfaces are implemented
+var _ ports.VisitRepository = (*PostgresStorage)(nil)
@@ -0,0 +1,67 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/sukryu/customer-id.git/internal/config"
+)
+
+// maxAttempts bounds how often a message is written before giving up,
+// per the publishing policy in docs/event-model.md (initial write plus retries).
+const maxAttempts = 3
+
+// Publisher publishes serialized domain events to Kafka.
+// Messages are hash-partitioned by key (the customer ID for identification events),
+// so that the events of one customer keep their order within a partition.
+type Publisher struct {
+	writer *kafka.Writer // Kafka writer handling batching, retries and acknowledgements
+}
+
+// NewPublisher creates a new Publisher writing to the configured broker and topic.
+// Failed writes are retried with exponential backoff starting at cfg.RetryBackoff.
+// The connection is established lazily on the first publish.
+// Returns an error if the configuration is invalid.
+func NewPublisher(cfg config.KafkaConfig) (*Publisher, error) {
+	if cfg.Broker == "" {
+		return nil, fmt.Errorf("kafka broker is required")
+	}
+	if cfg.Topic == "" {
+		return nil, fmt.Errorf("kafka topic is required")
+	}
+	if cfg.RetryBackoff <= 0 {
+		return nil, fmt.Errorf("kafka retry backoff must be positive")
+	}
+
+	return &Publisher{
+		writer: &kafka.Writer{
+			Addr:            kafka.TCP(cfg.Broker),
+			Topic:           cfg.Topic,
+			Balancer:        &kafka.Hash{},
+			MaxAttempts:     maxAttempts,
+			WriteBackoffMin: cfg.RetryBackoff,
+			WriteBackoffMax: cfg.RetryBackoff * (1 << (maxAttempts - 1)),
+			BatchTimeout:    10 * time.Millisecond, // Identification is latency sensitive; don't wait for full batches
+			RequiredAcks:    kafka.RequireAll,
+		},
+	}, nil
+}
+
+// Publish writes payload to the configured topic under key.
+// It blocks until the broker acknowledges the message or all attempts fail.
+func (p *Publisher) Publish(ctx context.Context, key string, payload []byte) error {
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(key),
+		Value: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to publish message with key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Close flushes pending messages and closes the underlying writer.
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sukryu/customer-id.git/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLogger builds a structured zap logger from the logging configuration.
+// Output may be "stdout" (default), "stderr", "file" (writes to FilePath), or a file path.
+// Returns an error if the level is unknown or the log file cannot be prepared.
+func NewLogger(cfg config.LoggingConfig) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	zapCfg := zap.NewProductionConfig()
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+	zapCfg.EncoderConfig.TimeKey = "timestamp"
+	zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	zapCfg.ErrorOutputPaths = []string{"stderr"}
+
+	switch cfg.Output {
+	case "", "stdout":
+		zapCfg.OutputPaths = []string{"stdout"}
+	case "stderr":
+		zapCfg.OutputPaths = []string{"stderr"}
+	case "file":
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("logging.file_path is required when output is 'file'")
+		}
+		if err := ensureLogDir(cfg.FilePath); err != nil {
+			return nil, err
+		}
+		zapCfg.OutputPaths = []string{cfg.FilePath}
+	default:
+		// Any other value is treated as a file path
+		if err := ensureLogDir(cfg.Output); err != nil {
+			return nil, err
+		}
+		zapCfg.OutputPaths = []string{cfg.Output}
+	}
+
+	logger, err := zapCfg.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build logger: %w", err)
+	}
+	return logger.With(zap.String("service", "customer-id")), nil
+}
+
+// ensureLogDir creates the parent directory of the log file if it does not exist.
+func ensureLogDir(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory for %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"go.uber.org/zap"
+)
+
+// Publisher defines the interface for delivering outbox messages to the message broker.
+type Publisher interface {
+	Publish(ctx context.Context, key string, payload []byte) error
+}
+
+// Relay drains the transactional outbox to a Publisher.
+// Every poll it relays batches until the outbox is empty or a delivery fails; failed
+// messages stay pending and are retried on the next poll. Delivery is at-least-once,
+// so consumers deduplicate by event_id.
+type Relay struct {
+	outbox    ports.OutboxRepository // Repository holding pending messages
+	publisher Publisher              // Destination of relayed messages
+	interval  time.Duration          // Delay between polls of the outbox
+	batchSize int                    // Maximum number of messages relayed per transaction
+	logger    *zap.Logger            // Logger for delivery failures
+}
+
+// NewRelay creates a new Relay polling outbox every interval and publishing up to
+// batchSize messages per transaction.
+// Returns an error if dependencies are invalid.
+func NewRelay(outbox ports.OutboxRepository, publisher Publisher, interval time.Duration, batchSize int, logger *zap.Logger) (*Relay, error) {
+	if outbox == nil {
+		return nil, fmt.Errorf("outbox repository is required")
+	}
+	if publisher == nil {
+		return nil, fmt.Errorf("publisher is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	return &Relay{
+		outbox:    outbox,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}, nil
+}
+
+// Run relays the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.Drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain relays batches until the outbox has no pending messages, a delivery fails or
+// ctx is cancelled. Returns the number of messages relayed.
+func (r *Relay) Drain(ctx context.Context) int {
+	total := 0
+	for ctx.Err() == nil {
+		sent, err := r.outbox.RelayOutbox(ctx, r.batchSize, r.deliver)
+		total += sent
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("Outbox relay failed, retrying on next poll", zap.Int("sent", sent), zap.Error(err))
+			}
+			break
+		}
+		if sent < r.batchSize {
+			break
+		}
+	}
+	return total
+}
+
+// deliver publishes a single outbox message.
+func (r *Relay) deliver(ctx context.Context, msg ports.OutboxMessage) error {
+	return r.publisher.Publish(ctx, msg.Key, msg.Payload)
+}
@@ -7,14 +7,19 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
 	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
 )
 
 // Cache provides methods to interact with Redis for caching customer identities.
-// It supports setting and retrieving CustomerIdentity data with TTL expiration.
+// It supports setting and retrieving CustomerIdentity data with TTL expiration, and
+// indexes the customers present in each store and at each of its locations. Signal
+// smoothers created from a Cache share its connection pool.
 type Cache interface {
+	ports.IdentityCache
+	ports.PresenceIndex
 	SetCustomerIdentity(ctx context.Context, identity *aggregates.CustomerIdentity) error
-	GetCustomerIdentity(ctx context.Context, customerID string) (*aggregates.CustomerIdentity, error)
+	SignalSmoother(window int, maxAge time.Duration) (SignalSmoother, error)
 	Close() error
 }
 
@@ -119,3 +124,6 @@ func (c *cache) Close() error {
 	}
 	return nil
 }
+
+// Verify interfaces are implemented
+var _ ports.IdentityCache = (*cache)(nil)
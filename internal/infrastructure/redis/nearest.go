@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// FindNearestBeacon retrieves the ID of the beacon deviceID was last placed at.
+// Placements expire together with the readings they were resolved from.
+// Returns an empty string if the placement is unknown or has expired.
+func (s *signalSmoother) FindNearestBeacon(ctx context.Context, deviceID entities.DeviceID) (string, error) {
+	key := fmt.Sprintf("nearest:%s", deviceID)
+	beaconID, err := s.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get nearest beacon from redis for key %s: %w", key, err)
+	}
+	return beaconID, nil
+}
+
+// SaveNearestBeacon records that deviceID was placed at beaconID for maxAge.
+// Returns an error if the operation fails.
+func (s *signalSmoother) SaveNearestBeacon(ctx context.Context, deviceID entities.DeviceID, beaconID string) error {
+	if beaconID == "" {
+		return fmt.Errorf("beaconID is required")
+	}
+	key := fmt.Sprintf("nearest:%s", deviceID)
+	if err := s.client.Set(ctx, key, beaconID, s.maxAge).Err(); err != nil {
+		return fmt.Errorf("failed to set nearest beacon in redis for key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Verify interfaces are implemented
+var _ ports.NearestBeaconRepository = (*signalSmoother)(nil)
@@ -0,0 +1,243 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+)
+
+// maxPresenceRetries bounds the attempts to update the presence of a customer whose
+// entry is changed concurrently by another identification.
+const maxPresenceRetries = 3
+
+// The presence index is kept in three kinds of keys:
+//   - "presence:customer:<customer ID>": hash with the store, location, arrival at the
+//     location, latest identification and expiry of the customer, expiring with the entry.
+//   - "presence:store:<store ID>": sorted set of the customers in the store, scored by
+//     expiry in unix milliseconds.
+//   - "presence:store:<store ID>:location:<location name>": the same for one location,
+//     named in lower case as locations are matched case-insensitively.
+//
+// Sorted set members cannot expire on their own; members that expired before an update
+// of their set are trimmed by it, and reads skip the rest.
+
+// presenceCustomerKey returns the key of the entry of customerID.
+func presenceCustomerKey(customerID string) string {
+	return fmt.Sprintf("presence:customer:%s", customerID)
+}
+
+// presenceStoreKey returns the key of the customers present in storeID.
+func presenceStoreKey(storeID string) string {
+	return fmt.Sprintf("presence:store:%s", storeID)
+}
+
+// presenceLocationKey returns the key of the customers present at location of storeID.
+func presenceLocationKey(storeID, location string) string {
+	return fmt.Sprintf("presence:store:%s:location:%s", storeID, strings.ToLower(location))
+}
+
+// UpdatePresence records that the customer of identity is at its store and location until
+// timeout has passed after identity was detected, moving the customer out of the sets of
+// any other store or location. The entry is read and rewritten in a transaction watching
+// it, so that concurrent identifications of the customer never leave stale memberships.
+// Returns an error if the identity is invalid or the operation fails.
+func (c *cache) UpdatePresence(ctx context.Context, identity *aggregates.CustomerIdentity, timeout time.Duration) error {
+	if identity == nil {
+		return fmt.Errorf("identity is required")
+	}
+	if err := identity.Validate(); err != nil {
+		return fmt.Errorf("invalid identity: %w", err)
+	}
+	if identity.StoreID == "" {
+		return fmt.Errorf("identity of customer %s has no store", identity.CustomerID)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got %s", timeout)
+	}
+
+	key := presenceCustomerKey(identity.CustomerID)
+	update := func(tx *redis.Tx) error {
+		previous, err := readOccupant(ctx, tx, identity.CustomerID)
+		if err != nil {
+			return err
+		}
+		if previous != nil && identity.DetectedAt.Before(previous.LastSeenAt) {
+			return nil // Late identification, the index has moved on
+		}
+
+		next := ports.Occupant{
+			CustomerID: identity.CustomerID,
+			StoreID:    identity.StoreID,
+			Location:   identity.Location,
+			Since:      identity.DetectedAt.UTC(),
+			LastSeenAt: identity.DetectedAt.UTC(),
+			ExpiresAt:  identity.DetectedAt.Add(timeout).UTC(),
+		}
+		if previous != nil && previous.StoreID == next.StoreID && next.Location.IsZero() {
+			next.Location = previous.Location // Unknown locations leave the customer where they were
+		}
+		if previous != nil && sameLocation(*previous, next) {
+			next.Since = previous.Since
+		}
+		location, err := json.Marshal(next.Location)
+		if err != nil {
+			return fmt.Errorf("failed to marshal location for key %s: %w", key, err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if previous != nil && !sameLocation(*previous, next) {
+				if previous.StoreID != next.StoreID {
+					pipe.ZRem(ctx, presenceStoreKey(previous.StoreID), identity.CustomerID)
+				}
+				if !previous.Location.IsZero() {
+					pipe.ZRem(ctx, presenceLocationKey(previous.StoreID, previous.Location.Name()), identity.CustomerID)
+				}
+			}
+			pipe.HSet(ctx, key,
+				"store_id", next.StoreID,
+				"location", location,
+				"since", next.Since.UnixMilli(),
+				"last_seen_at", next.LastSeenAt.UnixMilli(),
+				"expires_at", next.ExpiresAt.UnixMilli(),
+			)
+			pipe.PExpireAt(ctx, key, next.ExpiresAt)
+
+			sets := []string{presenceStoreKey(next.StoreID)}
+			if !next.Location.IsZero() {
+				sets = append(sets, presenceLocationKey(next.StoreID, next.Location.Name()))
+			}
+			expired := strconv.FormatInt(next.LastSeenAt.UnixMilli(), 10)
+			for _, set := range sets {
+				pipe.ZAdd(ctx, set, redis.Z{Score: float64(next.ExpiresAt.UnixMilli()), Member: identity.CustomerID})
+				pipe.ZRemRangeByScore(ctx, set, "-inf", "("+expired)
+			}
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxPresenceRetries; attempt++ {
+		err = c.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update presence in redis for key %s: %w", key, err)
+	}
+	return nil
+}
+
+// ListOccupants returns the customers present in storeID at now, or only those at location
+// if it is not empty, longest present at their location first.
+// Returns an empty slice if there are none, or an error if the operation fails.
+func (c *cache) ListOccupants(ctx context.Context, storeID, location string, now time.Time) ([]ports.Occupant, error) {
+	if storeID == "" {
+		return nil, fmt.Errorf("storeID is required")
+	}
+
+	set := presenceStoreKey(storeID)
+	if location != "" {
+		set = presenceLocationKey(storeID, location)
+	}
+	customerIDs, err := c.client.ZRangeByScore(ctx, set, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(now.UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list occupants from redis for key %s: %w", set, err)
+	}
+	if len(customerIDs) == 0 {
+		return []ports.Occupant{}, nil
+	}
+
+	entries := make([]*redis.MapStringStringCmd, len(customerIDs))
+	_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, customerID := range customerIDs {
+			entries[i] = pipe.HGetAll(ctx, presenceCustomerKey(customerID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occupants from redis for key %s: %w", set, err)
+	}
+
+	occupants := make([]ports.Occupant, 0, len(customerIDs))
+	for i, customerID := range customerIDs {
+		occupant, err := parseOccupant(customerID, entries[i].Val())
+		if err != nil {
+			return nil, err
+		}
+		// The set may still list customers who have since moved on or expired
+		if occupant == nil || occupant.StoreID != storeID || !occupant.ExpiresAt.After(now) {
+			continue
+		}
+		if location != "" && !strings.EqualFold(occupant.Location.Name(), location) {
+			continue
+		}
+		occupants = append(occupants, *occupant)
+	}
+
+	sort.Slice(occupants, func(i, j int) bool {
+		if !occupants[i].Since.Equal(occupants[j].Since) {
+			return occupants[i].Since.Before(occupants[j].Since)
+		}
+		return occupants[i].CustomerID < occupants[j].CustomerID
+	})
+	return occupants, nil
+}
+
+// readOccupant reads the entry of customerID within tx.
+// Returns nil if the customer is not present.
+func readOccupant(ctx context.Context, tx *redis.Tx, customerID string) (*ports.Occupant, error) {
+	key := presenceCustomerKey(customerID)
+	fields, err := tx.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presence from redis for key %s: %w", key, err)
+	}
+	return parseOccupant(customerID, fields)
+}
+
+// parseOccupant decodes the fields of the entry of customerID.
+// Returns nil if the entry is empty, i.e. the customer is not present.
+func parseOccupant(customerID string, fields map[string]string) (*ports.Occupant, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	key := presenceCustomerKey(customerID)
+	occupant := ports.Occupant{CustomerID: customerID, StoreID: fields["store_id"]}
+	if err := json.Unmarshal([]byte(fields["location"]), &occupant.Location); err != nil {
+		return nil, fmt.Errorf("malformed location for key %s: %w", key, err)
+	}
+	for field, t := range map[string]*time.Time{
+		"since":        &occupant.Since,
+		"last_seen_at": &occupant.LastSeenAt,
+		"expires_at":   &occupant.ExpiresAt,
+	} {
+		millis, err := strconv.ParseInt(fields[field], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed %s for key %s: %w", field, key, err)
+		}
+		*t = time.UnixMilli(millis).UTC()
+	}
+	return &occupant, nil
+}
+
+// sameLocation reports whether a and b are in the same store at the same location.
+func sameLocation(a, b ports.Occupant) bool {
+	return a.StoreID == b.StoreID && strings.EqualFold(a.Location.Name(), b.Location.Name())
+}
+
+// Verify interfaces are implemented
+var _ ports.PresenceIndex = (*cache)(nil)
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// SignalSmoother smooths RSSI readings with a moving average over a window of recent
+// readings per device/beacon pair, and remembers the beacon each device was last placed at.
+// State is stored in Redis so that readings reported to different replicas are combined.
+type SignalSmoother interface {
+	ports.SignalSmoother
+	ports.NearestBeaconRepository
+}
+
+// signalSmoother implements the SignalSmoother interface using Redis lists.
+type signalSmoother struct {
+	client *redis.Client // Redis client shared with the cache that created the smoother
+	window int           // Maximum number of readings averaged per pair
+	maxAge time.Duration // Age after which readings leave the window
+}
+
+// SignalSmoother creates a new SignalSmoother averaging up to window readings no older
+// than maxAge. The smoother shares the connection pool of the cache and is closed with it.
+// Returns an error if configuration is invalid.
+func (c *cache) SignalSmoother(window int, maxAge time.Duration) (SignalSmoother, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %d", window)
+	}
+	if maxAge <= 0 {
+		return nil, fmt.Errorf("maxAge must be positive, got %s", maxAge)
+	}
+	return &signalSmoother{
+		client: c.client,
+		window: window,
+		maxAge: maxAge,
+	}, nil
+}
+
+// Smooth adds the reading to the window of the pair and returns the signal averaged over
+// the readings taken within maxAge of detectedAt. Adding the reading and reading back the
+// window happen in one transaction, so concurrent readings of the pair are never lost.
+// Returns an error if the operation fails.
+func (s *signalSmoother) Smooth(ctx context.Context, deviceID entities.DeviceID, beaconID string, rssi int32, detectedAt time.Time) (entities.Signal, error) {
+	if beaconID == "" {
+		return entities.Signal{}, fmt.Errorf("beaconID is required")
+	}
+
+	// Define window key (e.g., "signal:app-install-1:550e8400-e29b-41d4-a716-446655440000");
+	// readings are stored newest first as "<unix millis>:<rssi>"
+	key := fmt.Sprintf("signal:%s:%s", deviceID, beaconID)
+	var window *redis.StringSliceCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, fmt.Sprintf("%d:%d", detectedAt.UnixMilli(), rssi))
+		pipe.LTrim(ctx, key, 0, int64(s.window-1))
+		pipe.PExpire(ctx, key, s.maxAge)
+		window = pipe.LRange(ctx, key, 0, -1)
+		return nil
+	})
+	if err != nil {
+		return entities.Signal{}, fmt.Errorf("failed to add reading to redis for key %s: %w", key, err)
+	}
+
+	oldest := detectedAt.Add(-s.maxAge).UnixMilli()
+	readings := make([]int32, 0, s.window)
+	for _, entry := range window.Val() {
+		at, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return entities.Signal{}, fmt.Errorf("malformed reading %q for key %s", entry, key)
+		}
+		millis, err := strconv.ParseInt(at, 10, 64)
+		if err != nil {
+			return entities.Signal{}, fmt.Errorf("malformed reading %q for key %s: %w", entry, key, err)
+		}
+		if millis < oldest {
+			continue
+		}
+		reading, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return entities.Signal{}, fmt.Errorf("malformed reading %q for key %s: %w", entry, key, err)
+		}
+		readings = append(readings, int32(reading))
+	}
+	return entities.NewSignalFromReadings(readings)
+}
+
+// Verify interfaces are implemented
+var _ ports.SignalSmoother = (*signalSmoother)(nil)
@@ -0,0 +1,89 @@
+package visits
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"go.uber.org/zap"
+)
+
+// Sweeper ends the visits of customers who have not been identified for the visit
+// timeout and did not return, which no identification would otherwise end.
+// Every poll it ends batches of expired visits until none is left or a batch fails;
+// failed batches are retried on the next poll. Each visit's VisitEnded event is
+// enqueued in the outbox together with the ended visit.
+type Sweeper struct {
+	visits    ports.VisitRepository // Repository holding open visits
+	interval  time.Duration         // Delay between sweeps
+	batchSize int                   // Maximum number of visits ended per transaction
+	logger    *zap.Logger           // Logger for sweep failures
+}
+
+// NewSweeper creates a new Sweeper sweeping visits every interval and ending up to
+// batchSize visits per transaction.
+// Returns an error if dependencies are invalid.
+func NewSweeper(visits ports.VisitRepository, interval time.Duration, batchSize int, logger *zap.Logger) (*Sweeper, error) {
+	if visits == nil {
+		return nil, fmt.Errorf("visit repository is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	return &Sweeper{
+		visits:    visits,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}, nil
+}
+
+// Run sweeps expired visits until ctx is cancelled.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep ends batches of visits expired at now until none is left, a batch fails or ctx
+// is cancelled. Returns the number of visits ended.
+func (s *Sweeper) Sweep(ctx context.Context, now time.Time) int {
+	total := 0
+	for ctx.Err() == nil {
+		ended, err := s.visits.EndExpiredVisits(ctx, now, s.batchSize, s.end)
+		total += ended
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Warn("Visit sweep failed, retrying on next poll", zap.Int("ended", total), zap.Error(err))
+			}
+			break
+		}
+		if ended < s.batchSize {
+			break
+		}
+	}
+	return total
+}
+
+// end ends a single expired visit.
+func (s *Sweeper) end(visit *aggregates.Visit) (events.Event, error) {
+	return services.EndVisit(visit)
+}
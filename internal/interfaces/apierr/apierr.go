@@ -0,0 +1,102 @@
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalMessage is returned to clients instead of the details of unexpected failures.
+const internalMessage = "internal server error"
+
+// unavailableMessage is returned to clients instead of the details of storage failures.
+const unavailableMessage = "service temporarily unavailable, retry later"
+
+// duplicateMessage is returned to clients instead of the details of duplicate inserts.
+const duplicateMessage = "identification already recorded"
+
+// FromError maps an identification error to a gRPC status code and a client-safe message.
+// Domain errors are mapped by their domainerr kind, so that clients can tell requests that
+// can never succeed (INVALID_ARGUMENT, FAILED_PRECONDITION, NOT_FOUND) from transient
+// failures worth retrying (UNAVAILABLE). Errors that already carry a gRPC status (e.g.
+// authorization denials) keep their code. Unexpected failures are reported as
+// codes.Internal with a generic message so that implementation details never leak to callers.
+func FromError(err error) (codes.Code, string) {
+	switch {
+	case err == nil:
+		return codes.OK, ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, "request timed out"
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, "request canceled"
+	case errors.Is(err, domainerr.ErrDuplicate):
+		return codes.AlreadyExists, duplicateMessage
+	case errors.Is(err, domainerr.ErrStorage):
+		return codes.Unavailable, unavailableMessage
+	case errors.Is(err, domainerr.ErrInvalidBeaconData), errors.Is(err, domainerr.ErrInvalidArgument):
+		return codes.InvalidArgument, err.Error()
+	case errors.Is(err, domainerr.ErrBeaconInactive):
+		return codes.FailedPrecondition, err.Error()
+	case errors.Is(err, domainerr.ErrBeaconNotFound), errors.Is(err, domainerr.ErrLowConfidence),
+		errors.Is(err, services.ErrCustomerNotIdentified), errors.Is(err, domainerr.ErrStoreNotFound):
+		return codes.NotFound, err.Error()
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code(), st.Message()
+	}
+	return codes.Internal, internalMessage
+}
+
+// HTTPStatus returns the HTTP status code corresponding to a gRPC status code.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499 // Client closed request
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorResponse is the JSON error envelope returned by the HTTP API.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail carries the gRPC status code and a human-readable message.
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// WriteHTTP writes the error envelope with the HTTP status matching code.
+func WriteHTTP(w http.ResponseWriter, code codes.Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(code))
+	_ = json.NewEncoder(w).Encode(ErrorResponse{
+		Error: ErrorDetail{Code: int(code), Message: message},
+	})
+}
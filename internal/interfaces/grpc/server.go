@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/internal/interfaces/apierr"
+	pb "github.com/sukryu/customer-id.git/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the customerid.CustomerID gRPC service.
+// It translates protobuf requests into domain value objects, delegates to the
+// identification and presence services, and maps domain failures to gRPC status codes.
+type Server struct {
+	pb.UnimplementedCustomerIDServer
+
+	identification services.IdentificationService // Domain service performing identification
+	presence       services.PresenceService       // Domain service reporting who is in each store
+	authorizer     *auth.Authorizer               // Enforces caller scopes and store access
+	qr             *auth.QRCodec                  // Verifies scanned QR payloads; nil disables QR identification
+	logger         *zap.Logger                    // Logger for unexpected failures
+}
+
+// NewServer creates a new gRPC Server backed by the given identification and presence
+// services. Every call is checked by authorizer against the caller's claims. qr may be
+// nil, in which case IdentifyByQR returns UNIMPLEMENTED.
+// Returns an error if dependencies are invalid.
+func NewServer(identification services.IdentificationService, presence services.PresenceService, authorizer *auth.Authorizer, qr *auth.QRCodec, logger *zap.Logger) (*Server, error) {
+	if identification == nil {
+		return nil, fmt.Errorf("identification service is required")
+	}
+	if presence == nil {
+		return nil, fmt.Errorf("presence service is required")
+	}
+	if authorizer == nil {
+		return nil, fmt.Errorf("authorizer is required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	return &Server{
+		identification: identification,
+		presence:       presence,
+		authorizer:     authorizer,
+		qr:             qr,
+		logger:         logger,
+	}, nil
+}
+
+// IdentifyCustomer identifies a customer from a single beacon detection.
+// A customer identified within the dedupe window is returned with already_identified set.
+// Returns INVALID_ARGUMENT for malformed requests, PERMISSION_DENIED when the beacon
+// belongs to a store outside the caller's claims, NOT_FOUND when the customer
+// cannot be identified, and INTERNAL for any other failure.
+func (s *Server) IdentifyCustomer(ctx context.Context, req *pb.IdentifyRequest) (*pb.IdentifyResponse, error) {
+	deviceID, err := entities.NewDeviceID(req.GetDeviceId())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid device ID: %w", err))
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.GetTimestamp())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid timestamp: %w", err))
+	}
+	beaconData, err := entities.NewBeaconData(req.GetUuid(), req.GetMajor(), req.GetMinor(), req.GetRssi())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid beacon data: %w", err))
+	}
+	beaconData = beaconData.WithDetectedAt(detectedAt)
+	if err := s.authorizer.AuthorizeIdentify(ctx, beaconData.UUID()); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	result, err := s.identification.IdentifyCustomer(ctx, deviceID, beaconData)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return toResponse(result), nil
+}
+
+// IdentifyScan identifies a customer at the nearest of the beacons detected in one scan.
+// Returns INVALID_ARGUMENT for malformed requests, PERMISSION_DENIED when any beacon
+// belongs to a store outside the caller's claims, NOT_FOUND when the customer cannot be
+// identified, and INTERNAL for any other failure.
+func (s *Server) IdentifyScan(ctx context.Context, req *pb.IdentifyScanRequest) (*pb.IdentifyResponse, error) {
+	deviceID, err := entities.NewDeviceID(req.GetDeviceId())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid device ID: %w", err))
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.GetTimestamp())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid timestamp: %w", err))
+	}
+	readings := make([]entities.BeaconData, 0, len(req.GetBeacons()))
+	for i, b := range req.GetBeacons() {
+		beaconData, err := entities.NewBeaconData(b.GetUuid(), b.GetMajor(), b.GetMinor(), b.GetRssi())
+		if err != nil {
+			return nil, s.toStatus(fmt.Errorf("invalid beacon data at index %d: %w", i, err))
+		}
+		readings = append(readings, beaconData.WithDetectedAt(detectedAt))
+	}
+	scan, err := entities.NewBeaconScan(readings)
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid beacon scan: %w", err))
+	}
+	if err := s.authorizer.AuthorizeScan(ctx, scan); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	result, err := s.identification.IdentifyScan(ctx, deviceID, scan)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return toResponse(result), nil
+}
+
+// IdentifyByQR identifies a customer from a scanned QR code.
+// Returns INVALID_ARGUMENT for malformed requests and payloads whose signature does not
+// match, PERMISSION_DENIED when the code belongs to a store outside the caller's claims,
+// NOT_FOUND when the customer cannot be identified, UNIMPLEMENTED when no QR signing key
+// is configured, and INTERNAL for any other failure.
+func (s *Server) IdentifyByQR(ctx context.Context, req *pb.IdentifyQRRequest) (*pb.IdentifyResponse, error) {
+	if s.qr == nil {
+		return nil, status.Error(codes.Unimplemented, "QR identification is not enabled")
+	}
+	deviceID, err := entities.NewDeviceID(req.GetDeviceId())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid device ID: %w", err))
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.GetTimestamp())
+	if err != nil {
+		return nil, s.toStatus(fmt.Errorf("invalid timestamp: %w", err))
+	}
+	qr, err := s.qr.Decode(req.GetPayload())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	qr = qr.WithDetectedAt(detectedAt)
+	if err := s.authorizer.AuthorizeStore(ctx, auth.ScopeIdentify, qr.StoreID()); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	result, err := s.identification.IdentifyByQR(ctx, deviceID, qr)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return toResponse(result), nil
+}
+
+// ListOccupants lists the customers present in a store, or at one of its locations,
+// longest present at their location first.
+// Returns INVALID_ARGUMENT for a missing store ID, PERMISSION_DENIED when the store is
+// outside the caller's claims or the caller lacks the read:customers scope, NOT_FOUND for
+// unknown stores, and UNAVAILABLE or INTERNAL for any other failure.
+func (s *Server) ListOccupants(ctx context.Context, req *pb.ListOccupantsRequest) (*pb.ListOccupantsResponse, error) {
+	if req.GetStoreId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id is required")
+	}
+	if err := s.authorizer.AuthorizeStore(ctx, auth.ScopeReadCustomers, req.GetStoreId()); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	occupants, err := s.presence.ListOccupants(ctx, req.GetStoreId(), req.GetLocation())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &pb.ListOccupantsResponse{Occupants: make([]*pb.Occupant, 0, len(occupants))}
+	for _, occupant := range occupants {
+		resp.Occupants = append(resp.Occupants, &pb.Occupant{
+			CustomerId:   occupant.CustomerID,
+			Location:     occupant.Location.Name(),
+			LocationType: string(occupant.Location.Type()),
+			Since:        occupant.Since.UTC().Format(time.RFC3339Nano),
+			LastSeenAt:   occupant.LastSeenAt.UTC().Format(time.RFC3339Nano),
+		})
+	}
+	return resp, nil
+}
+
+// GetOccupancy counts the customers present in a store by zone of its floor layout.
+// Returns the same errors as ListOccupants.
+func (s *Server) GetOccupancy(ctx context.Context, req *pb.GetOccupancyRequest) (*pb.GetOccupancyResponse, error) {
+	if req.GetStoreId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id is required")
+	}
+	if err := s.authorizer.AuthorizeStore(ctx, auth.ScopeReadCustomers, req.GetStoreId()); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	occupancy, err := s.presence.GetOccupancy(ctx, req.GetStoreId())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &pb.GetOccupancyResponse{
+		StoreId: occupancy.StoreID,
+		Total:   int32(occupancy.Total),
+		Zones:   make([]*pb.ZoneOccupancy, 0, len(occupancy.Zones)),
+		Unzoned: int32(occupancy.Unzoned),
+	}
+	for _, zone := range occupancy.Zones {
+		resp.Zones = append(resp.Zones, &pb.ZoneOccupancy{
+			Zone:  zone.Zone,
+			Floor: int32(zone.Floor),
+			Count: int32(zone.Count),
+		})
+	}
+	return resp, nil
+}
+
+// toResponse converts an identification result into its protobuf response.
+func toResponse(result *services.Identification) *pb.IdentifyResponse {
+	return &pb.IdentifyResponse{
+		CustomerId:        result.Identity.GetCustomerID(),
+		Location:          result.Identity.GetLocation().Name(),
+		LocationType:      string(result.Identity.GetLocation().Type()),
+		Confidence:        result.Identity.GetConfidence(),
+		AlreadyIdentified: result.Outcome == services.OutcomeAlreadyIdentified,
+	}
+}
+
+// toStatus maps an error to a gRPC status error with apierr.FromError.
+// Internal and storage failures are logged and returned without implementation details.
+func (s *Server) toStatus(err error) error {
+	code, message := apierr.FromError(err)
+	switch code {
+	case codes.Internal:
+		s.logger.Error("Request failed", zap.Error(err))
+	case codes.Unavailable:
+		s.logger.Warn("Request failed on storage", zap.Error(err))
+	}
+	return status.Error(code, message)
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/internal/interfaces/apierr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// maxRequestBytes bounds the size of identify request bodies.
+const maxRequestBytes = 64 << 10
+
+// IdentifyRequest is the JSON body accepted by POST /customer-id/identify.
+type IdentifyRequest struct {
+	DeviceID  string `json:"device_id"`
+	UUID      string `json:"uuid"`
+	Major     int32  `json:"major"`
+	Minor     int32  `json:"minor"`
+	RSSI      int32  `json:"rssi"`
+	Timestamp string `json:"timestamp"`
+}
+
+// BeaconReading is the detection of one beacon within an IdentifyScanRequest.
+type BeaconReading struct {
+	UUID  string `json:"uuid"`
+	Major int32  `json:"major"`
+	Minor int32  `json:"minor"`
+	RSSI  int32  `json:"rssi"`
+}
+
+// IdentifyScanRequest is the JSON body accepted by POST /customer-id/identify/scan.
+type IdentifyScanRequest struct {
+	DeviceID  string          `json:"device_id"`
+	Beacons   []BeaconReading `json:"beacons"`
+	Timestamp string          `json:"timestamp"`
+}
+
+// IdentifyQRRequest is the JSON body accepted by POST /customer-id/identify/qr.
+type IdentifyQRRequest struct {
+	DeviceID  string `json:"device_id"`
+	Payload   string `json:"payload"`
+	Timestamp string `json:"timestamp"`
+}
+
+// IdentifyResponse is the JSON body returned on successful identification.
+// AlreadyIdentified is set when the customer was identified within the dedupe window;
+// the other fields then describe that earlier identification.
+type IdentifyResponse struct {
+	CustomerID        string  `json:"customer_id"`
+	Location          string  `json:"location"`
+	LocationType      string  `json:"location_type,omitempty"`
+	Confidence        float32 `json:"confidence"`
+	AlreadyIdentified bool    `json:"already_identified"`
+}
+
+// OccupantResponse describes a customer present in a store.
+type OccupantResponse struct {
+	CustomerID   string    `json:"customer_id"`
+	Location     string    `json:"location"`
+	LocationType string    `json:"location_type,omitempty"`
+	Since        time.Time `json:"since"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+// ListOccupantsResponse is the JSON body returned by GET /customer-id/stores/{store_id}/occupants.
+type ListOccupantsResponse struct {
+	Occupants []OccupantResponse `json:"occupants"`
+}
+
+// ZoneOccupancyResponse counts the customers present in one zone of a store.
+type ZoneOccupancyResponse struct {
+	Zone  string `json:"zone"`
+	Floor int    `json:"floor"`
+	Count int    `json:"count"`
+}
+
+// OccupancyResponse is the JSON body returned by GET /customer-id/stores/{store_id}/occupancy.
+type OccupancyResponse struct {
+	StoreID string                  `json:"store_id"`
+	Total   int                     `json:"total"`
+	Zones   []ZoneOccupancyResponse `json:"zones"`
+	Unzoned int                     `json:"unzoned"`
+}
+
+// Handler serves the JSON/HTTP identification and presence API.
+// It mirrors the gRPC contract for clients that cannot speak gRPC.
+type Handler struct {
+	identification services.IdentificationService // Domain service performing identification
+	presence       services.PresenceService       // Domain service reporting who is in each store
+	authorizer     *auth.Authorizer               // Enforces caller scopes and store access
+	qr             *auth.QRCodec                  // Verifies scanned QR payloads; nil disables QR identification
+	timeout        time.Duration                  // Per-request processing deadline
+	logger         *zap.Logger                    // Logger for unexpected failures
+}
+
+// NewHandler creates a new HTTP Handler backed by the given identification and presence
+// services. Every request is checked by authorizer and bounded by timeout. qr may be nil,
+// in which case QR identification answers 501 Not Implemented.
+// Returns an error if dependencies are invalid.
+func NewHandler(identification services.IdentificationService, presence services.PresenceService, authorizer *auth.Authorizer, qr *auth.QRCodec, timeout time.Duration, logger *zap.Logger) (*Handler, error) {
+	if identification == nil {
+		return nil, fmt.Errorf("identification service is required")
+	}
+	if presence == nil {
+		return nil, fmt.Errorf("presence service is required")
+	}
+	if authorizer == nil {
+		return nil, fmt.Errorf("authorizer is required")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	return &Handler{
+		identification: identification,
+		presence:       presence,
+		authorizer:     authorizer,
+		qr:             qr,
+		timeout:        timeout,
+		logger:         logger,
+	}, nil
+}
+
+// Register adds the identification and presence routes to mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /customer-id/identify", h.identify)
+	mux.HandleFunc("POST /customer-id/identify/scan", h.identifyScan)
+	mux.HandleFunc("POST /customer-id/identify/qr", h.identifyQR)
+	mux.HandleFunc("GET /customer-id/stores/{store_id}/occupants", h.listOccupants)
+	mux.HandleFunc("GET /customer-id/stores/{store_id}/occupancy", h.getOccupancy)
+}
+
+// identify handles POST /customer-id/identify.
+func (h *Handler) identify(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	var req IdentifyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		apierr.WriteHTTP(w, codes.InvalidArgument, fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+
+	deviceID, err := entities.NewDeviceID(req.DeviceID)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid device ID: %w", err))
+		return
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.Timestamp)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid timestamp: %w", err))
+		return
+	}
+	beaconData, err := entities.NewBeaconData(req.UUID, req.Major, req.Minor, req.RSSI)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid beacon data: %w", err))
+		return
+	}
+	beaconData = beaconData.WithDetectedAt(detectedAt)
+
+	if err := h.authorizer.AuthorizeIdentify(ctx, beaconData.UUID()); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	result, err := h.identification.IdentifyCustomer(ctx, deviceID, beaconData)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	writeIdentification(w, result)
+}
+
+// identifyScan handles POST /customer-id/identify/scan.
+func (h *Handler) identifyScan(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	var req IdentifyScanRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		apierr.WriteHTTP(w, codes.InvalidArgument, fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+
+	deviceID, err := entities.NewDeviceID(req.DeviceID)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid device ID: %w", err))
+		return
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.Timestamp)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid timestamp: %w", err))
+		return
+	}
+	readings := make([]entities.BeaconData, 0, len(req.Beacons))
+	for i, b := range req.Beacons {
+		beaconData, err := entities.NewBeaconData(b.UUID, b.Major, b.Minor, b.RSSI)
+		if err != nil {
+			h.writeError(w, fmt.Errorf("invalid beacon data at index %d: %w", i, err))
+			return
+		}
+		readings = append(readings, beaconData.WithDetectedAt(detectedAt))
+	}
+	scan, err := entities.NewBeaconScan(readings)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid beacon scan: %w", err))
+		return
+	}
+
+	if err := h.authorizer.AuthorizeScan(ctx, scan); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	result, err := h.identification.IdentifyScan(ctx, deviceID, scan)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	writeIdentification(w, result)
+}
+
+// identifyQR handles POST /customer-id/identify/qr.
+func (h *Handler) identifyQR(w http.ResponseWriter, r *http.Request) {
+	if h.qr == nil {
+		apierr.WriteHTTP(w, codes.Unimplemented, "QR identification is not enabled")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	var req IdentifyQRRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		apierr.WriteHTTP(w, codes.InvalidArgument, fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+
+	deviceID, err := entities.NewDeviceID(req.DeviceID)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid device ID: %w", err))
+		return
+	}
+	detectedAt, err := entities.ParseDetectedAt(req.Timestamp)
+	if err != nil {
+		h.writeError(w, fmt.Errorf("invalid timestamp: %w", err))
+		return
+	}
+	qr, err := h.qr.Decode(req.Payload)
+	if err != nil {
+		apierr.WriteHTTP(w, codes.InvalidArgument, err.Error())
+		return
+	}
+	qr = qr.WithDetectedAt(detectedAt)
+
+	if err := h.authorizer.AuthorizeStore(ctx, auth.ScopeIdentify, qr.StoreID()); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	result, err := h.identification.IdentifyByQR(ctx, deviceID, qr)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	writeIdentification(w, result)
+}
+
+// listOccupants handles GET /customer-id/stores/{store_id}/occupants, optionally
+// restricted to one location with the location query parameter.
+func (h *Handler) listOccupants(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	storeID := r.PathValue("store_id")
+	if err := h.authorizer.AuthorizeStore(ctx, auth.ScopeReadCustomers, storeID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	occupants, err := h.presence.ListOccupants(ctx, storeID, r.URL.Query().Get("location"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := ListOccupantsResponse{Occupants: make([]OccupantResponse, 0, len(occupants))}
+	for _, occupant := range occupants {
+		resp.Occupants = append(resp.Occupants, OccupantResponse{
+			CustomerID:   occupant.CustomerID,
+			Location:     occupant.Location.Name(),
+			LocationType: string(occupant.Location.Type()),
+			Since:        occupant.Since.UTC(),
+			LastSeenAt:   occupant.LastSeenAt.UTC(),
+		})
+	}
+	writeJSON(w, resp)
+}
+
+// getOccupancy handles GET /customer-id/stores/{store_id}/occupancy.
+func (h *Handler) getOccupancy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	storeID := r.PathValue("store_id")
+	if err := h.authorizer.AuthorizeStore(ctx, auth.ScopeReadCustomers, storeID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	occupancy, err := h.presence.GetOccupancy(ctx, storeID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := OccupancyResponse{
+		StoreID: occupancy.StoreID,
+		Total:   occupancy.Total,
+		Zones:   make([]ZoneOccupancyResponse, 0, len(occupancy.Zones)),
+		Unzoned: occupancy.Unzoned,
+	}
+	for _, zone := range occupancy.Zones {
+		resp.Zones = append(resp.Zones, ZoneOccupancyResponse{Zone: zone.Zone, Floor: zone.Floor, Count: zone.Count})
+	}
+	writeJSON(w, resp)
+}
+
+// writeJSON writes body as a 200 OK JSON response.
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeIdentification writes the IdentifyResponse for result.
+func writeIdentification(w http.ResponseWriter, result *services.Identification) {
+	writeJSON(w, IdentifyResponse{
+		CustomerID:        result.Identity.GetCustomerID(),
+		Location:          result.Identity.GetLocation().Name(),
+		LocationType:      string(result.Identity.GetLocation().Type()),
+		Confidence:        result.Identity.GetConfidence(),
+		AlreadyIdentified: result.Outcome == services.OutcomeAlreadyIdentified,
+	})
+}
+
+// writeError writes the error envelope for err mapped with apierr.FromError, logging
+// internal and storage failures.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	code, message := apierr.FromError(err)
+	switch code {
+	case codes.Internal:
+		h.logger.Error("Request failed", zap.Error(err))
+	case codes.Unavailable:
+		h.logger.Warn("Request failed on storage", zap.Error(err))
+	}
+	apierr.WriteHTTP(w, code, message)
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/customer_id.proto
+
+package customerid
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CustomerID_IdentifyCustomer_FullMethodName = "/customerid.CustomerID/IdentifyCustomer"
+	CustomerID_IdentifyByQR_FullMethodName     = "/customerid.CustomerID/IdentifyByQR"
+	CustomerID_IdentifyScan_FullMethodName     = "/customerid.CustomerID/IdentifyScan"
+	CustomerID_ListOccupants_FullMethodName    = "/customerid.CustomerID/ListOccupants"
+	CustomerID_GetOccupancy_FullMethodName     = "/customerid.CustomerID/GetOccupancy"
+)
+
+// CustomerIDClient is the client API for CustomerID service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CustomerID identifies customers from in-store proximity signals.
+type CustomerIDClient interface {
+	// IdentifyCustomer identifies a customer based on beacon or QRS data.
+	IdentifyCustomer(ctx context.Context, in *IdentifyRequest, opts ...grpc.CallOption) (*IdentifyResponse, error)
+	// IdentifyByQR identifies a customer from a scanned, signed QR code.
+	IdentifyByQR(ctx context.Context, in *IdentifyQRRequest, opts ...grpc.CallOption) (*IdentifyResponse, error)
+	// IdentifyScan identifies a customer at the nearest of all beacons seen in one scan.
+	IdentifyScan(ctx context.Context, in *IdentifyScanRequest, opts ...grpc.CallOption) (*IdentifyResponse, error)
+	// ListOccupants lists the customers present in a store right now, optionally at one location.
+	ListOccupants(ctx context.Context, in *ListOccupantsRequest, opts ...grpc.CallOption) (*ListOccupantsResponse, error)
+	// GetOccupancy counts the customers present in a store by zone of its floor layout.
+	GetOccupancy(ctx context.Context, in *GetOccupancyRequest, opts ...grpc.CallOption) (*GetOccupancyResponse, error)
+}
+
+type customerIDClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCustomerIDClient(cc grpc.ClientConnInterface) CustomerIDClient {
+	return &customerIDClient{cc}
+}
+
+func (c *customerIDClient) IdentifyCustomer(ctx context.Context, in *IdentifyRequest, opts ...grpc.CallOption) (*IdentifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdentifyResponse)
+	err := c.cc.Invoke(ctx, CustomerID_IdentifyCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerIDClient) IdentifyByQR(ctx context.Context, in *IdentifyQRRequest, opts ...grpc.CallOption) (*IdentifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdentifyResponse)
+	err := c.cc.Invoke(ctx, CustomerID_IdentifyByQR_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerIDClient) IdentifyScan(ctx context.Context, in *IdentifyScanRequest, opts ...grpc.CallOption) (*IdentifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdentifyResponse)
+	err := c.cc.Invoke(ctx, CustomerID_IdentifyScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerIDClient) ListOccupants(ctx context.Context, in *ListOccupantsRequest, opts ...grpc.CallOption) (*ListOccupantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOccupantsResponse)
+	err := c.cc.Invoke(ctx, CustomerID_ListOccupants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerIDClient) GetOccupancy(ctx context.Context, in *GetOccupancyRequest, opts ...grpc.CallOption) (*GetOccupancyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOccupancyResponse)
+	err := c.cc.Invoke(ctx, CustomerID_GetOccupancy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomerIDServer is the server API for CustomerID service.
+// All implementations must embed UnimplementedCustomerIDServer
+// for forward compatibility.
+//
+// CustomerID identifies customers from in-store proximity signals.
+type CustomerIDServer interface {
+	// IdentifyCustomer identifies a customer based on beacon or QRS data.
+	IdentifyCustomer(context.Context, *IdentifyRequest) (*IdentifyResponse, error)
+	// IdentifyByQR identifies a customer from a scanned, signed QR code.
+	IdentifyByQR(context.Context, *IdentifyQRRequest) (*IdentifyResponse, error)
+	// IdentifyScan identifies a customer at the nearest of all beacons seen in one scan.
+	IdentifyScan(context.Context, *IdentifyScanRequest) (*IdentifyResponse, error)
+	// ListOccupants lists the customers present in a store right now, optionally at one location.
+	ListOccupants(context.Context, *ListOccupantsRequest) (*ListOccupantsResponse, error)
+	// GetOccupancy counts the customers present in a store by zone of its floor layout.
+	GetOccupancy(context.Context, *GetOccupancyRequest) (*GetOccupancyResponse, error)
+	mustEmbedUnimplementedCustomerIDServer()
+}
+
+// UnimplementedCustomerIDServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCustomerIDServer struct{}
+
+func (UnimplementedCustomerIDServer) IdentifyCustomer(context.Context, *IdentifyRequest) (*IdentifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentifyCustomer not implemented")
+}
+func (UnimplementedCustomerIDServer) IdentifyByQR(context.Context, *IdentifyQRRequest) (*IdentifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentifyByQR not implemented")
+}
+func (UnimplementedCustomerIDServer) IdentifyScan(context.Context, *IdentifyScanRequest) (*IdentifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentifyScan not implemented")
+}
+func (UnimplementedCustomerIDServer) ListOccupants(context.Context, *ListOccupantsRequest) (*ListOccupantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOccupants not implemented")
+}
+func (UnimplementedCustomerIDServer) GetOccupancy(context.Context, *GetOccupancyRequest) (*GetOccupancyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOccupancy not implemented")
+}
+func (UnimplementedCustomerIDServer) mustEmbedUnimplementedCustomerIDServer() {}
+func (UnimplementedCustomerIDServer) testEmbeddedByValue()                    {}
+
+// UnsafeCustomerIDServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CustomerIDServer will
+// result in compilation errors.
+type UnsafeCustomerIDServer interface {
+	mustEmbedUnimplementedCustomerIDServer()
+}
+
+func RegisterCustomerIDServer(s grpc.ServiceRegistrar, srv CustomerIDServer) {
+	// If the following call pancis, it indicates UnimplementedCustomerIDServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CustomerID_ServiceDesc, srv)
+}
+
+func _CustomerID_IdentifyCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerIDServer).IdentifyCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerID_IdentifyCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerIDServer).IdentifyCustomer(ctx, req.(*IdentifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerID_IdentifyByQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifyQRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerIDServer).IdentifyByQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerID_IdentifyByQR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerIDServer).IdentifyByQR(ctx, req.(*IdentifyQRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerID_IdentifyScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifyScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerIDServer).IdentifyScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerID_IdentifyScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerIDServer).IdentifyScan(ctx, req.(*IdentifyScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerID_ListOccupants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOccupantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerIDServer).ListOccupants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerID_ListOccupants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerIDServer).ListOccupants(ctx, req.(*ListOccupantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerID_GetOccupancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOccupancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerIDServer).GetOccupancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerID_GetOccupancy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerIDServer).GetOccupancy(ctx, req.(*GetOccupancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CustomerID_ServiceDesc is the grpc.ServiceDesc for CustomerID service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CustomerID_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "customerid.CustomerID",
+	HandlerType: (*CustomerIDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IdentifyCustomer",
+			Handler:    _CustomerID_IdentifyCustomer_Handler,
+		},
+		{
+			MethodName: "IdentifyByQR",
+			Handler:    _CustomerID_IdentifyByQR_Handler,
+		},
+		{
+			MethodName: "IdentifyScan",
+			Handler:    _CustomerID_IdentifyScan_Handler,
+		},
+		{
+			MethodName: "ListOccupants",
+			Handler:    _CustomerID_ListOccupants_Handler,
+		},
+		{
+			MethodName: "GetOccupancy",
+			Handler:    _CustomerID_GetOccupancy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/customer_id.proto",
+}
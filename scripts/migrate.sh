@@ -1,27 +1,28 @@
 #!/bin/bash
-# migrate.sh initializes the PostgreSQL database schema for the TasteSync customer-id service.
-# It applies the schema.sql file to the specified database using environment variables for configuration.
+# migrate.sh applies the schema migrations embedded in the customer-id binary.
+# Arguments are passed to "customer-id migrate" (default: up), e.g.
+#   scripts/migrate.sh status
+#   scripts/migrate.sh to 2
 
 # 꼭 권한 설정 해줘야 함. chmod +x scripts/migrate.sh
 
-# Default values if environment variables are not set
-DB_HOST=${DB_HOST:-localhost}
-DB_PORT=${DB_PORT:-5432}
-DB_USER=${DB_USER:-tastesync}
-DB_PASSWORD=${DB_PASSWORD:-secret}
-DB_NAME=${DB_NAME:-tastesync}
+set -euo pipefail
 
-# Export password to avoid interactive prompt
-export PGPASSWORD=$DB_PASSWORD
+# Connection settings are mapped onto the service configuration (postgres.* in config.yaml)
+if [ -n "${DB_HOST:-}" ]; then
+    export TASTESYNC_POSTGRES_HOST="$DB_HOST:${DB_PORT:-5432}"
+fi
+[ -n "${DB_USER:-}" ] && export TASTESYNC_POSTGRES_USER="$DB_USER"
+[ -n "${DB_PASSWORD:-}" ] && export TASTESYNC_POSTGRES_PASSWORD="$DB_PASSWORD"
+[ -n "${DB_NAME:-}" ] && export TASTESYNC_POSTGRES_DATABASE="$DB_NAME"
 
-# Apply schema.sql to the database
-echo "Applying schema to PostgreSQL database: $DB_NAME at $DB_HOST:$DB_PORT"
-psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -f internal/infrastructure/db/schema.sql
-
-# Check if migration was successful
-if [ $? -eq 0 ]; then
-    echo "Database schema initialized successfully."
+# Use a built binary when available, otherwise run from source
+BIN=${CUSTOMER_ID_BIN:-bin/customer-id}
+if [ $# -eq 0 ]; then
+    set -- up
+fi
+if [ -x "$BIN" ]; then
+    "$BIN" migrate "$@"
 else
-    echo "Failed to initialize database schema." >&2
-    exit 1
-fi
\ No newline at end of file
+    go run ./cmd/server migrate "$@"
+fi
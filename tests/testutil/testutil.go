@@ -0,0 +1,18 @@
+// Package testutil provides fixtures shared by the unit tests.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+)
+
+// MustLocation returns the Location named name of locationType, stopping the test if the
+// fixture is invalid.
+func MustLocation(t testing.TB, name string, locationType entities.LocationType) entities.Location {
+	t.Helper()
+	location, err := entities.NewLocation(name, locationType)
+	require.NoError(t, err, "Invalid location fixture")
+	return location
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
 )
 
 func TestNewCustomerIdentity(t *testing.T) {
@@ -17,12 +18,12 @@ func TestNewCustomerIdentity(t *testing.T) {
 	// Set LastSeen to a time more than 1 minute ago to avoid duplicate check
 	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
 
-	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, "Table 3", entities.StatusActive)
+	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
 	assert.NoError(t, err, "Failed to create beacon")
 
 	// Create CustomerIdentity
 	detectedAt := time.Now().UTC()
-	ci, err := aggregates.NewCustomerIdentity(cust, beacon, 0.95, detectedAt)
+	ci, err := aggregates.NewCustomerIdentity(cust, beacon, 0.95, detectedAt, aggregates.DefaultIdentificationPolicy())
 	if !assert.NoError(t, err, "Expected no error creating CustomerIdentity") {
 		t.Logf("CustomerIdentity creation failed: %v", err)
 		return
@@ -34,7 +35,7 @@ func TestNewCustomerIdentity(t *testing.T) {
 	// Validate config values
 	assert.Equal(t, "cust123", ci.GetCustomerID(), "CustomerID mismatch")
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", ci.GetBeaconID(), "BeaconID mismatch")
-	assert.Equal(t, "Table 3", ci.GetLocation(), "Location mismatch")
+	assert.Equal(t, "Table 3", ci.GetLocation().Name(), "Location mismatch")
 	assert.Equal(t, float32(0.95), ci.GetConfidence(), "Confidence mismatch")
 	assert.Equal(t, detectedAt, ci.GetDetectedAt(), "DetectedAt mismatch")
 
@@ -46,18 +47,56 @@ func TestNewCustomerIdentity(t *testing.T) {
 func TestNewCustomerIdentityLowConfidence(t *testing.T) {
 	cust, _ := entities.NewCustomer("cust123", nil)
 	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute) // Avoid duplicate check
-	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, "Table 3", entities.StatusActive)
-	_, err := aggregates.NewCustomerIdentity(cust, beacon, 0.7, time.Now().UTC())
+	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	_, err := aggregates.NewCustomerIdentity(cust, beacon, 0.7, time.Now().UTC(), aggregates.DefaultIdentificationPolicy())
 	assert.Error(t, err, "Expected error for low confidence")
 	assert.Contains(t, err.Error(), "confidence must be at least 0.8", "Error should indicate low confidence")
 }
 
-func TestNewCustomerIdentityDuplicate(t *testing.T) {
+func TestNewCustomerIdentityFirstVisit(t *testing.T) {
+	// A customer created just now has never been identified, so the first sighting succeeds
 	cust, _ := entities.NewCustomer("cust123", nil)
-	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, "Table 3", entities.StatusActive)
+	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	ci, err := aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC(), aggregates.DefaultIdentificationPolicy())
+	assert.NoError(t, err, "Expected first sighting to be identified")
+	assert.False(t, aggregates.DefaultIdentificationPolicy().IsDuplicate(nil, "store100", ci.GetLocation(), ci.GetDetectedAt()), "First sighting is not a duplicate")
+}
+
+func TestIdentificationPolicyIsDuplicate(t *testing.T) {
 	now := time.Now().UTC()
-	cust.LastSeen = now.Add(-30 * time.Second) // Within 1 minute
-	_, err := aggregates.NewCustomerIdentity(cust, beacon, 0.95, now)
-	assert.Error(t, err, "Expected error for duplicate identification")
-	assert.Contains(t, err.Error(), "duplicate identification within 1 minute", "Error should indicate duplicate")
+	table3 := testutil.MustLocation(t, "Table 3", entities.LocationTypeTable)
+	last := &aggregates.CustomerIdentity{CustomerID: "cust123", StoreID: "store100", Location: table3, DetectedAt: now.Add(-30 * time.Second)}
+	policy := aggregates.DefaultIdentificationPolicy()
+	assert.True(t, policy.IsDuplicate(last, "store100", table3, now), "Expected duplicate within 1 minute")
+	assert.False(t, policy.IsDuplicate(last, "store100", table3, now.Add(time.Minute)), "Expected no duplicate after 1 minute")
+	assert.True(t, policy.IsDuplicate(last, "store100", entities.Location{}, now), "Unknown locations do not move the customer")
+
+	table5 := testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)
+	assert.False(t, policy.IsDuplicate(last, "store100", table5, now), "Moving to another location is not a duplicate")
+	assert.False(t, policy.IsDuplicate(last, "store200", table3, now), "Moving to another store is not a duplicate")
+	assert.True(t, policy.IsDuplicate(last, "store100", table5, now.Add(-time.Minute)), "Readings before the latest identification are duplicates")
+
+	policy.DedupeWindow = 5 * time.Minute
+	assert.True(t, policy.IsDuplicate(last, "store100", table3, now.Add(time.Minute)), "Expected dedupe window of the policy")
+}
+
+func TestNewQRCustomerIdentity(t *testing.T) {
+	cust, _ := entities.NewCustomer("cust123", nil)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute) // Avoid duplicate check
+	qr, err := entities.NewQRData("store100", testutil.MustLocation(t, "Table 7", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+
+	ci, err := aggregates.NewQRCustomerIdentity(cust, qr, time.Now().UTC(), aggregates.DefaultIdentificationPolicy())
+	if !assert.NoError(t, err, "Expected no error creating CustomerIdentity") {
+		return
+	}
+	assert.Equal(t, aggregates.SourceQR, ci.GetSource(), "Source mismatch")
+	assert.Equal(t, "store100", ci.GetStoreID(), "StoreID mismatch")
+	assert.Equal(t, "Table 7", ci.GetLocation().Name(), "Location mismatch")
+	assert.Empty(t, ci.GetBeaconID(), "QR identities have no beacon")
+	assert.Equal(t, float32(1.0), ci.GetConfidence(), "QR identities are certain")
+	assert.NoError(t, ci.Validate(), "Validation should pass")
+
+	ci.BeaconID = "550e8400-e29b-41d4-a716-446655440000"
+	assert.Error(t, ci.Validate(), "QR identities must not reference a beacon")
 }
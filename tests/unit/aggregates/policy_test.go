@@ -0,0 +1,75 @@
+package aggregates_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func TestIdentificationPolicyValidate(t *testing.T) {
+	assert.NoError(t, aggregates.DefaultIdentificationPolicy().Validate(), "Default policy should be valid")
+
+	policy := aggregates.DefaultIdentificationPolicy()
+	policy.MinConfidence = 1.5
+	assert.Error(t, policy.Validate(), "Expected error for minimum confidence above 1.0")
+
+	policy = aggregates.DefaultIdentificationPolicy()
+	policy.AllowedBeaconStatuses = nil
+	assert.Error(t, policy.Validate(), "Expected error without allowed beacon statuses")
+
+	policy = aggregates.DefaultIdentificationPolicy()
+	policy.AllowedBeaconStatuses = []entities.BeaconStatus{"broken"}
+	assert.Error(t, policy.Validate(), "Expected error for unknown beacon status")
+
+	policy = aggregates.DefaultIdentificationPolicy()
+	policy.MaxClockSkew = -time.Second
+	assert.Error(t, policy.Validate(), "Expected error for negative clock skew")
+
+	policy = aggregates.DefaultIdentificationPolicy()
+	policy.MaxDetectionAge = 0
+	assert.Error(t, policy.Validate(), "Expected error without a detection age")
+
+	policy = aggregates.DefaultIdentificationPolicy()
+	policy.VisitTimeout = policy.DedupeWindow
+	assert.Error(t, policy.Validate(), "Expected error for a visit timeout not longer than the dedupe window")
+}
+
+func TestIdentificationPolicies(t *testing.T) {
+	bar := aggregates.DefaultIdentificationPolicy()
+	bar.MinConfidence = 0.6
+	policies, err := aggregates.NewIdentificationPolicies(aggregates.DefaultIdentificationPolicy(), map[string]aggregates.IdentificationPolicy{"Bar7": bar})
+	assert.NoError(t, err, "Failed to create policies")
+
+	assert.Equal(t, float32(0.6), policies.For("bar7").MinConfidence, "Store policy should match case-insensitively")
+	assert.Equal(t, float32(0.8), policies.For("store100").MinConfidence, "Other stores should use the default policy")
+
+	invalid := aggregates.DefaultIdentificationPolicy()
+	invalid.MinConfidence = -1
+	_, err = aggregates.NewIdentificationPolicies(aggregates.DefaultIdentificationPolicy(), map[string]aggregates.IdentificationPolicy{"bar7": invalid})
+	assert.Error(t, err, "Expected error for invalid store policy")
+}
+
+func TestNewCustomerIdentityPolicy(t *testing.T) {
+	cust, _ := entities.NewCustomer("cust123", nil)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusMaintenance)
+
+	policy := aggregates.DefaultIdentificationPolicy()
+	_, err := aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC(), policy)
+	assert.Error(t, err, "Expected error for beacon status outside the policy")
+
+	policy.AllowedBeaconStatuses = []entities.BeaconStatus{entities.StatusActive, entities.StatusMaintenance}
+	policy.MinConfidence = 0.5
+	_, err = aggregates.NewCustomerIdentity(cust, beacon, 0.6, time.Now().UTC(), policy)
+	assert.NoError(t, err, "Policy should allow maintenance beacons and lower confidence")
+
+	_, err = aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC().Add(time.Hour), policy)
+	assert.ErrorContains(t, err, "clock skew", "Expected error for detection beyond the clock skew")
+
+	_, err = aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC().Add(-time.Hour), policy)
+	assert.ErrorContains(t, err, "allowed age", "Expected error for detection older than the allowed age")
+}
@@ -0,0 +1,76 @@
+package aggregates_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func TestNewStore(t *testing.T) {
+	store, err := aggregates.NewStore("store100", "Gangnam Branch", "Asia/Seoul")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Asia/Seoul", store.Timezone.String(), "Timezone mismatch")
+	assert.NoError(t, store.Validate())
+
+	_, err = aggregates.NewStore("store100", "Gangnam Branch", "Mars/Olympus")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidArgument, "Expected error for unknown timezone")
+	_, err = aggregates.NewStore("store100", "", "Asia/Seoul")
+	assert.Error(t, err, "Expected error without a name")
+	_, err = aggregates.NewStore("", "Gangnam Branch", "Asia/Seoul")
+	assert.Error(t, err, "Expected error without a store ID")
+}
+
+func TestStoreIsOpenAt(t *testing.T) {
+	store, err := aggregates.NewStore("bar7", "Bar 7", "Asia/Seoul")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, store.IsOpenAt(time.Now()), "Stores without opening hours are always open")
+
+	// Open Fridays from 18:00 to 02:00 local time (UTC+9)
+	assert.NoError(t, store.SetOpeningHours([]aggregates.OpeningHours{{Weekday: time.Friday, Opens: 18 * time.Hour, Closes: 2 * time.Hour}}))
+	friday := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC) // Friday 09:00 in Seoul
+	assert.False(t, store.IsOpenAt(friday), "Closed Friday morning")
+	assert.True(t, store.IsOpenAt(friday.Add(10*time.Hour)), "Open Friday 19:00")
+	assert.True(t, store.IsOpenAt(friday.Add(16*time.Hour+30*time.Minute)), "Open past midnight, Saturday 01:30")
+	assert.False(t, store.IsOpenAt(friday.Add(17*time.Hour)), "Closed at 02:00")
+	assert.False(t, store.IsOpenAt(friday.Add(18*time.Hour)), "Closed Saturday 03:00")
+	assert.Equal(t, 18, store.LocalTime(friday.Add(9*time.Hour)).Hour(), "Local time should use the store's timezone")
+
+	err = store.SetOpeningHours([]aggregates.OpeningHours{{Weekday: time.Monday, Opens: 9 * time.Hour, Closes: 9 * time.Hour}})
+	assert.Error(t, err, "Expected error for a period closing when it opens")
+	assert.Len(t, store.OpeningHours, 1, "Rejected hours leave the opening hours unchanged")
+}
+
+func TestStoreZones(t *testing.T) {
+	store, err := aggregates.NewStore("store100", "Gangnam Branch", "Asia/Seoul")
+	if !assert.NoError(t, err) {
+		return
+	}
+	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	assert.NoError(t, err)
+	assert.NoError(t, store.CheckBeacon(beacon), "Any location is allowed without a floor layout")
+
+	assert.NoError(t, store.AddZone(aggregates.Zone{Name: "Terrace", Floor: 0, Locations: []string{"Table 10", "Table 11"}}))
+	assert.Error(t, store.AddZone(aggregates.Zone{Name: "terrace"}), "Zone names must be unique")
+	assert.Error(t, store.AddZone(aggregates.Zone{Name: "Hall", Locations: []string{"table 10"}}), "A location belongs to one zone")
+
+	zone, ok := store.ZoneOf("table 11")
+	assert.True(t, ok, "Locations should match case-insensitively")
+	assert.Equal(t, "Terrace", zone.Name)
+
+	assert.Error(t, store.CheckBeacon(beacon), "Beacons must be placed in a zone of the layout")
+	assert.NoError(t, store.AddZone(aggregates.Zone{Name: "Hall", Floor: 1, Locations: []string{"Table 3"}}))
+	assert.NoError(t, store.CheckBeacon(beacon))
+
+	other, err := entities.NewBeacon("660e8400-e29b-41d4-a716-446655440000", "store200", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	assert.NoError(t, err)
+	assert.Error(t, store.CheckBeacon(other), "Beacons of other stores do not belong to the store")
+}
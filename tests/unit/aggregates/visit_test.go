@@ -0,0 +1,103 @@
+package aggregates_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func visitIdentity(location entities.Location, detectedAt time.Time) *aggregates.CustomerIdentity {
+	return &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Location:   location,
+		Confidence: 0.95,
+		DetectedAt: detectedAt,
+	}
+}
+
+func TestStartVisit(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	entrance := testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance)
+
+	visit, err := aggregates.StartVisit(visitIdentity(entrance, start), 30*time.Minute)
+	if !assert.NoError(t, err, "Failed to start visit") {
+		return
+	}
+	assert.Len(t, visit.VisitID, 36, "VisitID should be a UUID")
+	assert.Equal(t, "cust123", visit.CustomerID)
+	assert.Equal(t, "store100", visit.StoreID)
+	assert.Equal(t, start, visit.StartedAt)
+	assert.True(t, visit.IsOpen(), "New visits are open")
+	assert.Equal(t, entrance, visit.CurrentStay().Location)
+	assert.Equal(t, start.Add(30*time.Minute), visit.ExpiresAt())
+
+	_, err = aggregates.StartVisit(nil, 30*time.Minute)
+	assert.Error(t, err, "Expected error for missing identity")
+	_, err = aggregates.StartVisit(visitIdentity(entrance, start), 0)
+	assert.Error(t, err, "Expected error without a timeout")
+}
+
+func TestVisitRecord(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	entrance := testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance)
+	table5 := testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)
+	visit, err := aggregates.StartVisit(visitIdentity(entrance, start), 30*time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	moved, err := visit.Record(visitIdentity(table5, start.Add(2*time.Minute)))
+	assert.NoError(t, err)
+	assert.True(t, moved, "Identification at another location is a move")
+	moved, err = visit.Record(visitIdentity(table5, start.Add(42*time.Minute)))
+	assert.NoError(t, err)
+	assert.False(t, moved, "Identification at the same location extends the stay")
+	moved, err = visit.Record(visitIdentity(entities.Location{}, start.Add(43*time.Minute)))
+	assert.NoError(t, err)
+	assert.False(t, moved, "Identification at an unknown location extends the stay")
+	moved, err = visit.Record(visitIdentity(entrance, start.Add(10*time.Minute)))
+	assert.NoError(t, err)
+	assert.False(t, moved, "Late identifications change nothing")
+
+	if assert.Len(t, visit.Stays, 2) {
+		assert.Equal(t, 2*time.Minute, visit.Stays[0].Duration(), "Time at the entrance mismatch")
+		assert.Equal(t, table5, visit.CurrentStay().Location)
+		assert.Equal(t, 41*time.Minute, visit.CurrentStay().Duration(), "Time at the table mismatch")
+	}
+	assert.Equal(t, start.Add(43*time.Minute), visit.LastSeenAt)
+	assert.Equal(t, 43*time.Minute, visit.Duration())
+	assert.NoError(t, visit.Validate())
+
+	other := visitIdentity(table5, start.Add(44*time.Minute))
+	other.StoreID = "store200"
+	_, err = visit.Record(other)
+	assert.Error(t, err, "Expected error for an identification in another store")
+}
+
+func TestVisitEnd(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	table5 := testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)
+	visit, err := aggregates.StartVisit(visitIdentity(table5, start), 30*time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = visit.Record(visitIdentity(table5, start.Add(40*time.Minute)))
+	assert.NoError(t, err)
+
+	assert.False(t, visit.IsExpiredAt(start.Add(69*time.Minute)), "Visit should be open within the timeout")
+	assert.True(t, visit.IsExpiredAt(start.Add(70*time.Minute)), "Visit should expire after the timeout")
+
+	assert.NoError(t, visit.End())
+	assert.False(t, visit.IsOpen())
+	assert.Equal(t, start.Add(40*time.Minute), visit.EndedAt, "Visits end when the customer was last seen")
+	assert.NoError(t, visit.Validate())
+	assert.Error(t, visit.End(), "Expected error for ending a visit twice")
+
+	_, err = visit.Record(visitIdentity(table5, start.Add(50*time.Minute)))
+	assert.Error(t, err, "Expected error for recording in an ended visit")
+}
@@ -0,0 +1,283 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testIssuer   = "tastesync"
+	testAudience = "customer-id"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Failed to generate RSA key")
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	assert.NoError(t, err, "Failed to sign token")
+	return token
+}
+
+func validClaims() jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Subject:   "pos-gateway",
+		Issuer:    testIssuer,
+		Audience:  jwt.ClaimStrings{testAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+}
+
+func newVerifier(t *testing.T, key *rsa.PrivateKey) *auth.Verifier {
+	verifier, err := auth.NewVerifier(&key.PublicKey, testIssuer, testAudience)
+	assert.NoError(t, err, "Failed to create verifier")
+	return verifier
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	key, err := auth.LoadPublicKey("../../../internal/config/keys/dev/public.pem")
+	assert.NoError(t, err, "Failed to load bundled dev public key")
+	assert.NotNil(t, key)
+}
+
+func TestVerify(t *testing.T) {
+	key := generateKey(t)
+	verifier := newVerifier(t, key)
+
+	claims, err := verifier.Verify(signToken(t, key, validClaims()))
+	assert.NoError(t, err, "Expected valid token to verify")
+	assert.Equal(t, "pos-gateway", claims.Subject, "Subject mismatch")
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	key := generateKey(t)
+	verifier := newVerifier(t, key)
+	now := time.Now()
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Hour))
+
+	notYetValid := validClaims()
+	notYetValid.NotBefore = jwt.NewNumericDate(now.Add(time.Hour))
+
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "someone-else"
+
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.ClaimStrings{"billing"}
+
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+
+	cases := map[string]string{
+		"expired":        signToken(t, key, expired),
+		"not yet valid":  signToken(t, key, notYetValid),
+		"wrong issuer":   signToken(t, key, wrongIssuer),
+		"wrong audience": signToken(t, key, wrongAudience),
+		"missing exp":    signToken(t, key, noExpiry),
+		"wrong key":      signToken(t, generateKey(t), validClaims()),
+		"malformed":      "not.a.token",
+	}
+	for name, token := range cases {
+		_, err := verifier.Verify(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken, "Expected %s token to be rejected", name)
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	key := generateKey(t)
+	verifier := newVerifier(t, key)
+
+	var subject string
+	handler := verifier.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = auth.SubjectFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Missing token
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/customer-id/identify", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected 401 without token")
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+
+	// Valid token
+	req := httptest.NewRequest(http.MethodPost, "/customer-id/identify", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, key, validClaims()))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 with valid token")
+	assert.Equal(t, "pos-gateway", subject, "Subject should be stored in the request context")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	key := generateKey(t)
+	verifier := newVerifier(t, key)
+	interceptor := verifier.UnaryServerInterceptor("/grpc.health.v1.Health/Check")
+
+	var subject string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		subject = auth.SubjectFromContext(ctx)
+		return "ok", nil
+	}
+	identify := &grpc.UnaryServerInfo{FullMethod: "/customerid.CustomerID/IdentifyCustomer"}
+
+	// Missing token
+	_, err := interceptor(context.Background(), nil, identify, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Expected UNAUTHENTICATED without token")
+
+	// Public method
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	assert.NoError(t, err, "Public methods should not require a token")
+
+	// Valid token
+	md := metadata.Pairs("authorization", "Bearer "+signToken(t, key, validClaims()))
+	_, err = interceptor(metadata.NewIncomingContext(context.Background(), md), nil, identify, handler)
+	assert.NoError(t, err, "Expected valid token to be accepted")
+	assert.Equal(t, "pos-gateway", subject, "Subject should be stored in the call context")
+}
+
+func TestIssue(t *testing.T) {
+	key := generateKey(t)
+	issuer, err := auth.NewIssuer(key, testIssuer, testAudience)
+	assert.NoError(t, err, "Failed to create issuer")
+
+	token, err := issuer.Issue("pos-gateway", []string{"identify"}, []string{"store100"}, time.Hour)
+	assert.NoError(t, err, "Failed to issue token")
+
+	claims, err := newVerifier(t, key).Verify(token)
+	if !assert.NoError(t, err, "Issued token should verify") {
+		return
+	}
+	assert.Equal(t, "pos-gateway", claims.Subject, "Subject mismatch")
+	assert.Equal(t, []string{"identify"}, claims.Scopes, "Scopes mismatch")
+	assert.Equal(t, []string{"store100"}, claims.Stores, "Stores mismatch")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, 5*time.Second, "Expiry mismatch")
+	assert.NotEmpty(t, claims.ID, "Token ID should be set")
+
+	_, err = issuer.Issue("", nil, nil, time.Hour)
+	assert.Error(t, err, "Expected error for missing subject")
+	_, err = issuer.Issue("pos-gateway", nil, nil, 0)
+	assert.Error(t, err, "Expected error for non-positive ttl")
+}
+
+type mockBeaconRepo struct {
+	beacons map[string]*entities.Beacon
+	err     error
+}
+
+func (r *mockBeaconRepo) FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error) {
+	return r.beacons[uuid], r.err
+}
+
+func TestAuthorizer(t *testing.T) {
+	authorizer, err := auth.NewAuthorizer(&mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Status:   entities.StatusActive,
+			},
+		},
+	})
+	assert.NoError(t, err, "Failed to create authorizer")
+	beaconID := "550e8400-e29b-41d4-a716-446655440000"
+
+	as := func(claims *auth.Claims) context.Context {
+		return auth.NewContext(context.Background(), claims)
+	}
+
+	err = authorizer.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store100"}}), beaconID)
+	assert.NoError(t, err, "Expected access to own store")
+
+	err = authorizer.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{auth.AllStores}}), beaconID)
+	assert.NoError(t, err, "Expected wildcard store claim to grant access")
+
+	err = authorizer.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store200"}}), beaconID)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected denial for another store's beacon")
+
+	err = authorizer.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeIdentify}}), beaconID)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected denial without store claims")
+
+	err = authorizer.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeAdminBeacons}, Stores: []string{"store100"}}), beaconID)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected denial without the identify scope")
+
+	err = authorizer.AuthorizeIdentify(context.Background(), beaconID)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Expected UNAUTHENTICATED without claims")
+
+	failing, err := auth.NewAuthorizer(&mockBeaconRepo{err: fmt.Errorf("connection refused")})
+	assert.NoError(t, err, "Failed to create authorizer")
+	err = failing.AuthorizeIdentify(as(&auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store100"}}), beaconID)
+	assert.ErrorIs(t, err, domainerr.ErrStorage, "Expected a storage error when the beacon cannot be resolved")
+}
+
+func TestQRCodec(t *testing.T) {
+	_, err := auth.NewQRCodec([]byte("too-short"))
+	assert.Error(t, err, "Expected error for a short key")
+
+	codec, err := auth.NewQRCodec([]byte("test-qr-signing-key-0123456789abcdef"))
+	assert.NoError(t, err)
+	nonce, err := auth.NewQRNonce()
+	assert.NoError(t, err)
+	qr, err := entities.NewQRData("store100", testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), nonce)
+	assert.NoError(t, err)
+
+	payload, err := codec.Encode(qr)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(payload, "TSQR1."), "Payload should carry the format prefix")
+	decoded, err := codec.Decode(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, qr, decoded, "Decoded QR data mismatch")
+
+	// Codes signed with another key or altered after signing are rejected
+	other, err := auth.NewQRCodec([]byte("another-qr-signing-key-0123456789ab"))
+	assert.NoError(t, err)
+	_, err = other.Decode(payload)
+	assert.ErrorIs(t, err, auth.ErrInvalidQRCode, "Expected signature mismatch for another key")
+
+	parts := strings.Split(payload, ".")
+	forgedBody := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"store200","l":"Table 3","n":"` + nonce + `"}`))
+	_, err = codec.Decode(parts[0] + "." + forgedBody + "." + parts[2])
+	assert.ErrorIs(t, err, auth.ErrInvalidQRCode, "Expected signature mismatch for an altered body")
+
+	// Codes printed before locations had a type carry none; it is inferred from the name
+	legacyBody := "TSQR1." + base64.RawURLEncoding.EncodeToString([]byte(`{"s":"store100","l":"Main Entrance","n":"`+nonce+`"}`))
+	mac := hmac.New(sha256.New, []byte("test-qr-signing-key-0123456789abcdef"))
+	mac.Write([]byte(legacyBody))
+	legacy, err := codec.Decode(legacyBody + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, testutil.MustLocation(t, "Main Entrance", entities.LocationTypeEntrance), legacy.Location(), "Legacy location type mismatch")
+
+	for _, malformed := range []string{"", "TSQR1", "TSQR2." + parts[1] + "." + parts[2], parts[0] + "." + parts[1] + ".!!"} {
+		_, err = codec.Decode(malformed)
+		assert.ErrorIs(t, err, auth.ErrInvalidQRCode, "Expected malformed payload %q to be rejected", malformed)
+	}
+}
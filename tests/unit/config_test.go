@@ -87,9 +87,23 @@ logging:
 	assert.Equal(t, 5*time.Second, cfg.Server.Timeout, "Timeout mismatch")
 	assert.Equal(t, "localhost:6379", cfg.Redis.Host, "Redis host mismatch")
 	assert.Equal(t, "testsecret", cfg.Postgres.Password, "Postgres password should be overridden by env")
+	assert.Equal(t, "monthly", cfg.Postgres.Partitions.Granularity, "Partition granularity should default to monthly")
+	assert.Equal(t, 3, cfg.Postgres.Partitions.Premake, "Partition premake should default to 3")
+	assert.Equal(t, time.Hour, cfg.Postgres.Partitions.CheckInterval, "Partition check interval should default to 1h")
 	assert.Equal(t, "keys/dev/private.pem", cfg.JWT.PrivateKeyPath, "JWT private key path mismatch")
 	assert.Equal(t, "keys/dev/public.pem", cfg.JWT.PublicKeyPath, "JWT public key path mismatch")
 	assert.Equal(t, "customer-events", cfg.Kafka.Topic, "Kafka topic mismatch")
+	assert.Equal(t, "path_loss", cfg.Confidence.Estimator, "Confidence estimator should default to path_loss")
+	assert.Equal(t, 2.0, cfg.Confidence.PathLoss.Exponent, "Path-loss exponent should default to 2")
+	assert.Equal(t, 5, cfg.Confidence.Smoothing.Window, "Smoothing window should default to 5")
+	assert.Equal(t, 6.0, cfg.Confidence.Smoothing.HysteresisMargin, "Hysteresis margin should default to 6")
+	assert.Equal(t, 0.8, *cfg.Policy.MinConfidence, "Minimum confidence should default to 0.8")
+	assert.Equal(t, time.Minute, *cfg.Policy.DedupeWindow, "Dedupe window should default to 1m")
+	assert.Equal(t, []string{"active"}, cfg.Policy.AllowedBeaconStatuses, "Only active beacons should be allowed by default")
+	assert.Equal(t, 30*time.Second, *cfg.Policy.MaxClockSkew, "Clock skew should default to 30s")
+	assert.Equal(t, 5*time.Minute, *cfg.Policy.MaxDetectionAge, "Detection age should default to 5m")
+	assert.Equal(t, 30*time.Minute, *cfg.Policy.VisitTimeout, "Visit timeout should default to 30m")
+	assert.Equal(t, time.Minute, cfg.Visits.SweepInterval, "Visit sweep interval should default to 1m")
 	assert.Equal(t, "info", cfg.Logging.Level, "Logging level mismatch")
 }
 
@@ -131,3 +145,94 @@ redis:
 	assert.Error(t, err, "Expected error when required fields are missing")
 	assert.Contains(t, err.Error(), "http_port must be between 1 and 65535", "Error should indicate invalid port")
 }
+
+// loadConfig loads the configuration of environment env from a config file with contents.
+func loadConfig(t *testing.T, env, contents string) (*config.Config, error) {
+	t.Helper()
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, "internal", "config")
+	assert.NoError(t, os.MkdirAll(configDir, 0755), "Failed to create config directory")
+	err := os.WriteFile(filepath.Join(configDir, "config-"+env+".yaml"), []byte(contents), 0644)
+	assert.NoError(t, err, "Failed to write config file")
+
+	assert.NoError(t, os.Chdir(tempDir), "Failed to change to temp directory")
+	t.Setenv("TASTESYNC_ENV", env)
+
+	return config.Load(zaptest.NewLogger(t))
+}
+
+const requiredConfig = `
+server:
+  http_port: 3000
+  grpc_port: 50051
+redis:
+  host: "localhost:6379"
+postgres:
+  host: "localhost:5432"
+  user: "tastesync"
+  database: "tastesync"
+jwt:
+  private_key: "keys/dev/private.pem"
+  public_key: "keys/dev/public.pem"
+kafka:
+  broker: "localhost:9092"
+  topic: "customer-events"
+`
+
+func TestLoadConfigZeroHysteresisMargin(t *testing.T) {
+	cfg, err := loadConfig(t, "nohysteresis", requiredConfig+`
+confidence:
+  smoothing:
+    hysteresis_margin: 0
+`)
+	if !assert.NoError(t, err, "Expected no error loading config") {
+		return
+	}
+	assert.Equal(t, 0.0, cfg.Confidence.Smoothing.HysteresisMargin, "An explicit margin of 0 disables hysteresis")
+
+	cfg, err = loadConfig(t, "negativehysteresis", requiredConfig+`
+confidence:
+  smoothing:
+    hysteresis_margin: -2
+`)
+	if !assert.NoError(t, err, "Expected no error loading config") {
+		return
+	}
+	assert.Equal(t, -2.0, cfg.Confidence.Smoothing.HysteresisMargin, "Negative margins are left for the resolver to reject")
+}
+
+func TestLoadConfigZeroPolicyRules(t *testing.T) {
+	cfg, err := loadConfig(t, "zeropolicy", requiredConfig+`
+policy:
+  min_confidence: 0
+  max_clock_skew: 0s
+  stores:
+    store100:
+      dedupe_window: 0s
+      min_confidence: 0.6
+`)
+	if !assert.NoError(t, err, "Expected no error loading config") {
+		return
+	}
+	if assert.NotNil(t, cfg.Policy.MinConfidence) {
+		assert.Equal(t, 0.0, *cfg.Policy.MinConfidence, "An explicit minimum confidence of 0 is kept")
+	}
+	if assert.NotNil(t, cfg.Policy.MaxClockSkew) {
+		assert.Equal(t, time.Duration(0), *cfg.Policy.MaxClockSkew, "An explicit clock skew of 0 is kept")
+	}
+	assert.Equal(t, time.Minute, *cfg.Policy.DedupeWindow, "Unset rules still default")
+
+	store := cfg.Policy.Stores["store100"]
+	if assert.NotNil(t, store.DedupeWindow, "An explicit store override of 0 is set") {
+		assert.Equal(t, time.Duration(0), *store.DedupeWindow)
+	}
+	assert.Nil(t, store.MaxClockSkew, "Rules a store does not override stay unset")
+
+	_, err = loadConfig(t, "negativepolicy", requiredConfig+`
+policy:
+  stores:
+    store100:
+      dedupe_window: -1m
+`)
+	assert.ErrorContains(t, err, "dedupe_window must not be negative")
+}
@@ -0,0 +1,21 @@
+package domainerr_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+)
+
+func TestErrorf(t *testing.T) {
+	err := domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve beacon: %w", context.Canceled)
+	assert.Equal(t, "failed to retrieve beacon: context canceled", err.Error(), "Kind must not change the message")
+	assert.ErrorIs(t, err, domainerr.ErrStorage, "Expected the kind")
+	assert.ErrorIs(t, err, context.Canceled, "Expected the wrapped error")
+	assert.NotErrorIs(t, err, domainerr.ErrInvalidArgument, "Unexpected kind")
+
+	wrapped := fmt.Errorf("invalid beacon scan: %w", domainerr.Errorf(domainerr.ErrInvalidBeaconData, "at least one beacon is required"))
+	assert.ErrorIs(t, wrapped, domainerr.ErrInvalidBeaconData, "Kind must survive wrapping")
+}
@@ -1,10 +1,15 @@
 package entities_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/tests/testutil"
 )
 
 func TestNewCustomer(t *testing.T) {
@@ -15,10 +20,24 @@ func TestNewCustomer(t *testing.T) {
 }
 
 func TestNewBeacon(t *testing.T) {
-	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, "Table 3", entities.StatusActive)
+	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
 	assert.NoError(t, err)
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", beacon.BeaconID)
 	assert.Equal(t, entities.StatusActive, beacon.Status)
+	assert.Equal(t, entities.DefaultTxPower, beacon.CalibratedTxPower(), "Uncalibrated beacons assume the default txPower")
+}
+
+func TestBeaconSetTxPower(t *testing.T) {
+	beacon, err := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	assert.NoError(t, err)
+
+	assert.NoError(t, beacon.SetTxPower(-65))
+	assert.Equal(t, int32(-65), beacon.CalibratedTxPower())
+	assert.NoError(t, beacon.Validate())
+
+	assert.Error(t, beacon.SetTxPower(4), "txPower above 0 dBm")
+	assert.Error(t, beacon.SetTxPower(-101), "txPower below -100 dBm")
+	assert.Equal(t, int32(-65), beacon.TxPower, "Rejected values leave the calibration unchanged")
 }
 
 func TestNewBeaconData(t *testing.T) {
@@ -33,10 +52,50 @@ func TestNewBeaconData(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBeaconDataWithDetectedAt(t *testing.T) {
+	bd, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -50)
+	assert.NoError(t, err)
+	assert.True(t, bd.DetectedAt().IsZero(), "Detection time should be unknown by default")
+
+	detectedAt := time.Date(2025, 3, 2, 21, 0, 0, 0, time.FixedZone("KST", 9*60*60))
+	detected := bd.WithDetectedAt(detectedAt)
+	assert.Equal(t, time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC), detected.DetectedAt(), "Detection time should be UTC")
+	assert.True(t, bd.DetectedAt().IsZero(), "WithDetectedAt should not modify the original")
+}
+
+func TestParseDetectedAt(t *testing.T) {
+	detectedAt, err := entities.ParseDetectedAt("2025-03-02T21:00:00+09:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC), detectedAt)
+
+	detectedAt, err = entities.ParseDetectedAt("")
+	assert.NoError(t, err)
+	assert.True(t, detectedAt.IsZero(), "An empty timestamp means unknown")
+
+	_, err = entities.ParseDetectedAt("2025-03-02 12:00")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidArgument, "Expected invalid argument for malformed timestamps")
+}
+
+func TestCustomerSeenAt(t *testing.T) {
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	now := time.Now().UTC()
+	cust.LastSeen = now
+
+	cust.SeenAt(now.Add(-time.Minute))
+	assert.Equal(t, now, cust.LastSeen, "Late readings should not move LastSeen back")
+	cust.SeenAt(now.Add(time.Second))
+	assert.Equal(t, now.Add(time.Second), cust.LastSeen, "Newer readings should move LastSeen forward")
+}
+
 func TestNewBeaconDataInvalidRSSI(t *testing.T) {
 	_, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, 10) // RSSI > 0
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "rssi must be between -100 and 0")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidBeaconData, "Expected invalid beacon data")
+
+	_, err = entities.NewDeviceID("")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidArgument, "Expected invalid argument")
 }
 
 func TestNewLocation(t *testing.T) {
@@ -54,3 +113,96 @@ func TestNewLocationInvalidType(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid location type")
 }
+
+func TestLegacyLocation(t *testing.T) {
+	assert.Equal(t, entities.LocationTypeEntrance, entities.LegacyLocation("Main Entrance").Type())
+	assert.Equal(t, entities.LocationTypeCounter, entities.LegacyLocation("Counter").Type())
+	assert.Equal(t, entities.LocationTypeTable, entities.LegacyLocation("Table 3").Type())
+	assert.True(t, entities.LegacyLocation("").IsZero(), "An empty name is an unknown location")
+}
+
+func TestLocationJSON(t *testing.T) {
+	loc := testutil.MustLocation(t, "Table 3", entities.LocationTypeTable)
+	data, err := json.Marshal(loc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Table 3","type":"table"}`, string(data))
+
+	var decoded entities.Location
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, loc, decoded, "Round trip mismatch")
+
+	// Locations cached before they had a type are plain strings
+	assert.NoError(t, json.Unmarshal([]byte(`"Entrance"`), &decoded))
+	assert.Equal(t, testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance), decoded)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &decoded))
+	assert.True(t, decoded.IsZero(), "null is an unknown location")
+
+	assert.Error(t, json.Unmarshal([]byte(`{"name":"Table 3","type":"kitchen"}`), &decoded), "Expected invalid type to be rejected")
+}
+
+func TestNewDeviceID(t *testing.T) {
+	deviceID, err := entities.NewDeviceID("9b2c6f3e-app-install")
+	assert.NoError(t, err)
+	assert.Equal(t, "9b2c6f3e-app-install", deviceID.String())
+
+	_, err = entities.NewDeviceID("")
+	assert.Error(t, err)
+	_, err = entities.NewDeviceID("has space")
+	assert.Error(t, err)
+	_, err = entities.NewDeviceID(strings.Repeat("a", 129))
+	assert.Error(t, err)
+}
+
+func TestNewQRData(t *testing.T) {
+	qr, err := entities.NewQRData("store100", testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+	assert.Equal(t, "store100", qr.StoreID())
+	assert.Equal(t, "Table 3", qr.Location().Name())
+	assert.Equal(t, "nonce-0001", qr.Nonce())
+
+	_, err = entities.NewQRData("", testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), "nonce-0001")
+	assert.Error(t, err)
+	_, err = entities.NewQRData("store100", entities.Location{}, "nonce-0001")
+	assert.Error(t, err)
+	_, err = entities.NewQRData("store100", testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), "short")
+	assert.Error(t, err)
+	_, err = entities.NewQRData("store100", testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), "nonce with spaces")
+	assert.Error(t, err)
+}
+
+func TestNewSignalFromReadings(t *testing.T) {
+	signal, err := entities.NewSignalFromReadings([]int32{-60, -70, -65, -65})
+	assert.NoError(t, err)
+	assert.Equal(t, -65.0, signal.RSSI())
+	assert.Equal(t, 4, signal.Samples())
+	assert.Equal(t, 12.5, signal.Variance())
+
+	single := entities.SingleReading(-50)
+	assert.Equal(t, -50.0, single.RSSI())
+	assert.Equal(t, 1, single.Samples())
+	assert.Equal(t, 0.0, single.StdDev())
+
+	_, err = entities.NewSignalFromReadings(nil)
+	assert.Error(t, err, "At least one reading is required")
+	_, err = entities.NewSignalFromReadings([]int32{-50, 10})
+	assert.Error(t, err, "Readings must be valid RSSI values")
+}
+
+func TestNewBeaconScan(t *testing.T) {
+	a, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -62)
+	assert.NoError(t, err)
+	b, err := entities.NewBeaconData("660e8400-e29b-41d4-a716-446655440000", 100, 4, -70)
+	assert.NoError(t, err)
+
+	scan, err := entities.NewBeaconScan([]entities.BeaconData{a, b})
+	assert.NoError(t, err)
+	assert.Len(t, scan.Readings(), 2)
+
+	_, err = entities.NewBeaconScan(nil)
+	assert.Error(t, err, "A scan needs at least one beacon")
+	_, err = entities.NewBeaconScan([]entities.BeaconData{a, b, a})
+	assert.Error(t, err, "A beacon may be reported only once")
+	_, err = entities.NewBeaconScan(make([]entities.BeaconData, entities.MaxScanBeacons+1))
+	assert.Error(t, err, "Scans are bounded")
+}
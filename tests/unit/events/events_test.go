@@ -0,0 +1,158 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func TestNewCustomerIdentified(t *testing.T) {
+	detectedAt := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.95,
+		DetectedAt: detectedAt,
+	}
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -50)
+	assert.NoError(t, err)
+
+	event, err := events.NewCustomerIdentified(identity, beaconData)
+	if !assert.NoError(t, err, "Failed to create event") {
+		return
+	}
+	assert.Len(t, event.EventID, 36, "EventID should be a UUID")
+	assert.Equal(t, "cust123", event.Key(), "Events should be keyed by customer ID")
+
+	other, err := events.NewCustomerIdentified(identity, beaconData)
+	assert.NoError(t, err)
+	assert.NotEqual(t, event.EventID, other.EventID, "EventIDs must be unique")
+
+	// The envelope must match the schema in docs/event-model.md
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+	var envelope map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &envelope))
+	assert.Equal(t, "CustomerIdentified", envelope["event_type"])
+	assert.Equal(t, "v1", envelope["version"])
+	assert.Contains(t, envelope, "timestamp")
+
+	data := envelope["data"].(map[string]interface{})
+	assert.Equal(t, "cust123", data["customer_id"])
+	assert.Equal(t, "Table 3", data["location"])
+	assert.Equal(t, "table", data["location_type"])
+	assert.InDelta(t, 0.95, data["confidence"], 0.0001)
+	assert.Equal(t, "2025-03-02T12:00:00Z", data["detected_at"])
+	assert.Equal(t, "beacon", data["source"])
+	assert.NotContains(t, data, "qr", "Beacon events carry no QR block")
+	assert.Equal(t, map[string]interface{}{
+		"uuid":  "550e8400-e29b-41d4-a716-446655440000",
+		"major": float64(100),
+		"minor": float64(3),
+		"rssi":  float64(-50),
+	}, data["beacon"])
+}
+
+func TestNewCustomerIdentifiedRequiresIdentity(t *testing.T) {
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -50)
+	assert.NoError(t, err)
+
+	_, err = events.NewCustomerIdentified(nil, beaconData)
+	assert.Error(t, err, "Expected error for missing identity")
+}
+
+func TestNewQRCustomerIdentified(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Source:     aggregates.SourceQR,
+		Location:   testutil.MustLocation(t, "Table 7", entities.LocationTypeTable),
+		Confidence: 1.0,
+		DetectedAt: time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC),
+	}
+	qr, err := entities.NewQRData("store100", testutil.MustLocation(t, "Table 7", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+
+	event, err := events.NewQRCustomerIdentified(identity, qr)
+	if !assert.NoError(t, err, "Failed to create event") {
+		return
+	}
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+	var envelope map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &envelope))
+
+	data := envelope["data"].(map[string]interface{})
+	assert.Equal(t, "qr", data["source"])
+	assert.Equal(t, "store100", data["store_id"])
+	assert.Equal(t, map[string]interface{}{"nonce": "nonce-0001"}, data["qr"])
+	assert.NotContains(t, data, "beacon", "QR events carry no beacon block")
+}
+
+func TestVisitEvents(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Location:   testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance),
+		Confidence: 0.95,
+		DetectedAt: start,
+	}
+	visit, err := aggregates.StartVisit(identity, 30*time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	started, err := events.NewVisitStarted(visit)
+	assert.NoError(t, err)
+	assert.Equal(t, events.TypeVisitStarted, started.Header().EventType)
+	assert.Equal(t, "cust123", started.Key(), "Visit events should be keyed by customer ID")
+	assert.Equal(t, entities.LocationTypeEntrance, started.Data.LocationType)
+	_, err = events.NewLocationChanged(visit)
+	assert.Error(t, err, "Expected error for a visit that has not moved")
+	_, err = events.NewVisitEnded(visit)
+	assert.Error(t, err, "Expected error for an open visit")
+
+	identity.Location = testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)
+	identity.DetectedAt = start.Add(2 * time.Minute)
+	_, err = visit.Record(identity)
+	assert.NoError(t, err)
+	changed, err := events.NewLocationChanged(visit)
+	if assert.NoError(t, err) {
+		payload, err := json.Marshal(changed)
+		assert.NoError(t, err)
+		var envelope map[string]interface{}
+		assert.NoError(t, json.Unmarshal(payload, &envelope))
+		assert.Equal(t, events.TypeLocationChanged, envelope["event_type"])
+		data := envelope["data"].(map[string]interface{})
+		assert.Equal(t, visit.VisitID, data["visit_id"])
+		assert.Equal(t, "Entrance", data["from"].(map[string]interface{})["location"])
+		assert.Equal(t, float64(120), data["from"].(map[string]interface{})["duration_seconds"])
+		assert.Equal(t, "table", data["to"].(map[string]interface{})["location_type"])
+		assert.Equal(t, "2025-03-02T12:02:00Z", data["changed_at"])
+	}
+
+	identity.DetectedAt = start.Add(42 * time.Minute)
+	_, err = visit.Record(identity)
+	assert.NoError(t, err)
+	assert.NoError(t, visit.End())
+	ended, err := events.NewVisitEnded(visit)
+	if assert.NoError(t, err) {
+		assert.Equal(t, events.TypeVisitEnded, ended.EventType)
+		assert.Equal(t, int64(42*60), ended.Data.DurationSeconds, "Visit duration mismatch")
+		if assert.Len(t, ended.Data.Stays, 2) {
+			assert.Equal(t, "Table 5", ended.Data.Stays[1].Location)
+			assert.Equal(t, int64(40*60), ended.Data.Stays[1].DurationSeconds, "Customer was seated at table 5 for 40 minutes")
+		}
+	}
+
+	_, err = events.NewVisitStarted(nil)
+	assert.Error(t, err, "Expected error for missing visit")
+}
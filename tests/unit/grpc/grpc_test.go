@@ -0,0 +1,399 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	grpcapi "github.com/sukryu/customer-id.git/internal/interfaces/grpc"
+	pb "github.com/sukryu/customer-id.git/proto"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockIdentificationService struct {
+	identity *aggregates.CustomerIdentity
+	outcome  services.Outcome // Outcome of successful calls; identified if empty
+	err      error
+	deviceID entities.DeviceID
+	beacon   entities.BeaconData
+	qr       entities.QRData
+	scan     entities.BeaconScan
+}
+
+func (s *mockIdentificationService) IdentifyScan(ctx context.Context, deviceID entities.DeviceID, scan entities.BeaconScan) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.scan = scan
+	return s.result()
+}
+
+func (s *mockIdentificationService) IdentifyByQR(ctx context.Context, deviceID entities.DeviceID, qr entities.QRData) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.qr = qr
+	return s.result()
+}
+
+func (s *mockIdentificationService) IdentifyCustomer(ctx context.Context, deviceID entities.DeviceID, beaconData entities.BeaconData) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.beacon = beaconData
+	return s.result()
+}
+
+func (s *mockIdentificationService) result() (*services.Identification, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	outcome := s.outcome
+	if outcome == "" {
+		outcome = services.OutcomeIdentified
+	}
+	return &services.Identification{Identity: s.identity, Outcome: outcome}, nil
+}
+
+type mockBeaconRepo struct {
+	beacons map[string]*entities.Beacon
+	err     error
+}
+
+func (r *mockBeaconRepo) FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error) {
+	return r.beacons[uuid], r.err
+}
+
+func newAuthorizer(t *testing.T) *auth.Authorizer {
+	authorizer, err := auth.NewAuthorizer(&mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+			"660e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "660e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store200",
+				Major:    200,
+				Minor:    1,
+				Location: testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance),
+				Status:   entities.StatusActive,
+			},
+		},
+	})
+	assert.NoError(t, err, "Failed to create authorizer")
+	return authorizer
+}
+
+// withClaims authenticates every call as a client holding claims.
+func withClaims(claims *auth.Claims) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(auth.NewContext(ctx, claims), req)
+	}
+}
+
+var identifyClaims = &auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store100"}}
+
+func newQRCodec(t *testing.T) *auth.QRCodec {
+	codec, err := auth.NewQRCodec([]byte("test-qr-signing-key-0123456789abcdef"))
+	assert.NoError(t, err, "Failed to create QR codec")
+	return codec
+}
+
+// qrPayload returns a payload signed by newQRCodec for a code at storeID.
+func qrPayload(t *testing.T, storeID string) string {
+	qr, err := entities.NewQRData(storeID, testutil.MustLocation(t, "Table 7", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+	payload, err := newQRCodec(t).Encode(qr)
+	assert.NoError(t, err)
+	return payload
+}
+
+// newClient starts the adapter on an in-memory listener and returns a connected client
+// whose calls are authenticated with claims.
+func newClient(t *testing.T, svc services.IdentificationService, claims *auth.Claims) pb.CustomerIDClient {
+	return dial(t, svc, &mockPresenceService{}, claims)
+}
+
+// dial starts the adapter backed by svc and presence on an in-memory listener and returns
+// a connected client whose calls are authenticated with claims.
+func dial(t *testing.T, svc services.IdentificationService, presence services.PresenceService, claims *auth.Claims) pb.CustomerIDClient {
+	server, err := grpcapi.NewServer(svc, presence, newAuthorizer(t), newQRCodec(t), zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create gRPC adapter")
+	return connect(t, server, claims)
+}
+
+// connect serves server on an in-memory listener and returns a connected client whose
+// calls are authenticated with claims.
+func connect(t *testing.T, server pb.CustomerIDServer, claims *auth.Claims) pb.CustomerIDClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(withClaims(claims)))
+	pb.RegisterCustomerIDServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err, "Failed to dial in-memory gRPC server")
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCustomerIDClient(conn)
+}
+
+func validRequest() *pb.IdentifyRequest {
+	return &pb.IdentifyRequest{
+		Uuid:      "550e8400-e29b-41d4-a716-446655440000",
+		Major:     100,
+		Minor:     3,
+		Rssi:      -20,
+		Timestamp: "2025-03-02T12:00:00Z",
+		DeviceId:  "app-install-1",
+	}
+}
+
+func TestIdentifyCustomer(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.95,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	client := newClient(t, svc, identifyClaims)
+
+	resp, err := client.IdentifyCustomer(context.Background(), validRequest())
+	if !assert.NoError(t, err, "Expected no error identifying customer") {
+		return
+	}
+	assert.Equal(t, "app-install-1", svc.deviceID.String(), "DeviceID mismatch")
+	assert.Equal(t, time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC), svc.beacon.DetectedAt(), "Expected the request timestamp as detection time")
+	assert.Equal(t, "cust123", resp.GetCustomerId(), "CustomerID mismatch")
+	assert.Equal(t, "Table 3", resp.GetLocation(), "Location mismatch")
+	assert.Equal(t, "table", resp.GetLocationType(), "Location type mismatch")
+	assert.Equal(t, float32(0.95), resp.GetConfidence(), "Confidence mismatch")
+	assert.False(t, resp.GetAlreadyIdentified(), "Expected a new identification")
+}
+
+func TestIdentifyCustomerAlreadyIdentified(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.9,
+		DetectedAt: time.Now().UTC().Add(-30 * time.Second),
+	}
+	svc := &mockIdentificationService{identity: identity, outcome: services.OutcomeAlreadyIdentified}
+	client := newClient(t, svc, identifyClaims)
+
+	resp, err := client.IdentifyCustomer(context.Background(), validRequest())
+	if !assert.NoError(t, err, "Expected already identified customers to succeed") {
+		return
+	}
+	assert.True(t, resp.GetAlreadyIdentified(), "Expected already_identified to be set")
+	assert.Equal(t, "cust123", resp.GetCustomerId(), "CustomerID mismatch")
+	assert.Equal(t, float32(0.9), resp.GetConfidence(), "Expected the earlier identification")
+}
+
+func TestIdentifyCustomerInvalidArgument(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	req := validRequest()
+	req.Uuid = "not-a-uuid"
+	_, err := client.IdentifyCustomer(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT for malformed UUID")
+}
+
+func TestIdentifyCustomerInvalidTimestamp(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	req := validRequest()
+	req.Timestamp = "yesterday"
+	_, err := client.IdentifyCustomer(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT for malformed timestamp")
+}
+
+func TestIdentifyCustomerMissingDeviceID(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	req := validRequest()
+	req.DeviceId = ""
+	_, err := client.IdentifyCustomer(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT without a device ID")
+}
+
+func TestIdentifyCustomerNotFound(t *testing.T) {
+	svcErr := fmt.Errorf("%w: beacon not found", services.ErrCustomerNotIdentified)
+	client := newClient(t, &mockIdentificationService{err: svcErr}, identifyClaims)
+
+	_, err := client.IdentifyCustomer(context.Background(), validRequest())
+	assert.Equal(t, codes.NotFound, status.Code(err), "Expected NOT_FOUND when identification is rejected")
+}
+
+func TestIdentifyCustomerInternal(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{err: fmt.Errorf("connection refused")}, identifyClaims)
+
+	_, err := client.IdentifyCustomer(context.Background(), validRequest())
+	assert.Equal(t, codes.Internal, status.Code(err), "Expected INTERNAL for infrastructure failures")
+	assert.NotContains(t, status.Convert(err).Message(), "connection refused", "Internal details must not leak")
+}
+
+func TestIdentifyCustomerDomainErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{domainerr.Errorf(domainerr.ErrInvalidArgument, "invalid device ID: device_id is required"), codes.InvalidArgument},
+		{domainerr.Errorf(domainerr.ErrBeaconNotFound, "%w: beacon not found", services.ErrCustomerNotIdentified), codes.NotFound},
+		{domainerr.Errorf(domainerr.ErrBeaconInactive, "%w: beacon is not active", services.ErrCustomerNotIdentified), codes.FailedPrecondition},
+		{domainerr.Errorf(domainerr.ErrLowConfidence, "%w: confidence too low", services.ErrCustomerNotIdentified), codes.NotFound},
+		{domainerr.Errorf(domainerr.ErrDuplicate, "identity already recorded: unique violation"), codes.AlreadyExists},
+		{domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve beacon: connection refused"), codes.Unavailable},
+	}
+	for _, tt := range tests {
+		client := newClient(t, &mockIdentificationService{err: tt.err}, identifyClaims)
+		_, err := client.IdentifyCustomer(context.Background(), validRequest())
+		assert.Equal(t, tt.code, status.Code(err), "Unexpected code for %v", tt.err)
+		assert.NotContains(t, status.Convert(err).Message(), "connection refused", "Storage details must not leak")
+	}
+}
+
+func TestIdentifyCustomerAuthorizationUnavailable(t *testing.T) {
+	authorizer, err := auth.NewAuthorizer(&mockBeaconRepo{err: fmt.Errorf("connection refused")})
+	assert.NoError(t, err, "Failed to create authorizer")
+	server, err := grpcapi.NewServer(&mockIdentificationService{}, &mockPresenceService{}, authorizer, newQRCodec(t), zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create gRPC adapter")
+	client := connect(t, server, identifyClaims)
+
+	_, err = client.IdentifyCustomer(context.Background(), validRequest())
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Expected UNAVAILABLE when the beacon store cannot be resolved")
+	assert.NotContains(t, status.Convert(err).Message(), "connection refused", "Storage details must not leak")
+}
+
+func TestIdentifyCustomerPermissionDenied(t *testing.T) {
+	otherStore := &auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store200"}}
+	client := newClient(t, &mockIdentificationService{}, otherStore)
+
+	_, err := client.IdentifyCustomer(context.Background(), validRequest())
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED for a beacon of another store")
+
+	noScope := &auth.Claims{Scopes: []string{auth.ScopeReadCustomers}, Stores: []string{"store100"}}
+	client = newClient(t, &mockIdentificationService{}, noScope)
+
+	_, err = client.IdentifyCustomer(context.Background(), validRequest())
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED without the identify scope")
+}
+
+func TestIdentifyByQR(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Source:     aggregates.SourceQR,
+		Location:   testutil.MustLocation(t, "Table 7", entities.LocationTypeTable),
+		Confidence: 1.0,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	client := newClient(t, svc, identifyClaims)
+
+	resp, err := client.IdentifyByQR(context.Background(), &pb.IdentifyQRRequest{
+		DeviceId: "app-install-1",
+		Payload:  qrPayload(t, "store100"),
+	})
+	if !assert.NoError(t, err, "Expected no error identifying customer") {
+		return
+	}
+	assert.Equal(t, "store100", svc.qr.StoreID(), "StoreID mismatch")
+	assert.Equal(t, "Table 7", svc.qr.Location().Name(), "Location mismatch")
+	assert.Equal(t, "cust123", resp.GetCustomerId(), "CustomerID mismatch")
+	assert.Equal(t, float32(1.0), resp.GetConfidence(), "Confidence mismatch")
+}
+
+func TestIdentifyByQRForged(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	payload := qrPayload(t, "store100")
+	_, err := client.IdentifyByQR(context.Background(), &pb.IdentifyQRRequest{
+		DeviceId: "app-install-1",
+		Payload:  payload[:len(payload)-2] + "xx",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT for a forged signature")
+}
+
+func TestIdentifyByQRPermissionDenied(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	_, err := client.IdentifyByQR(context.Background(), &pb.IdentifyQRRequest{
+		DeviceId: "app-install-1",
+		Payload:  qrPayload(t, "store200"),
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED for another store's code")
+}
+
+func TestIdentifyScan(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.95,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	client := newClient(t, svc, identifyClaims)
+
+	resp, err := client.IdentifyScan(context.Background(), &pb.IdentifyScanRequest{
+		DeviceId: "app-install-1",
+		Beacons: []*pb.BeaconReading{
+			{Uuid: "550e8400-e29b-41d4-a716-446655440000", Major: 100, Minor: 3, Rssi: -62},
+			{Uuid: "770e8400-e29b-41d4-a716-446655440000", Major: 100, Minor: 4, Rssi: -75},
+		},
+	})
+	if !assert.NoError(t, err, "Expected no error identifying customer") {
+		return
+	}
+	assert.Len(t, svc.scan.Readings(), 2, "Every beacon of the scan is passed on")
+	assert.Equal(t, "app-install-1", svc.deviceID.String(), "DeviceID mismatch")
+	assert.Equal(t, "Table 3", resp.GetLocation(), "Location mismatch")
+}
+
+func TestIdentifyScanInvalidArgument(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	_, err := client.IdentifyScan(context.Background(), &pb.IdentifyScanRequest{DeviceId: "app-install-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT for an empty scan")
+
+	reading := &pb.BeaconReading{Uuid: "550e8400-e29b-41d4-a716-446655440000", Major: 100, Minor: 3, Rssi: -62}
+	_, err = client.IdentifyScan(context.Background(), &pb.IdentifyScanRequest{
+		DeviceId: "app-install-1",
+		Beacons:  []*pb.BeaconReading{reading, reading},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT for a beacon reported twice")
+}
+
+func TestIdentifyScanPermissionDenied(t *testing.T) {
+	client := newClient(t, &mockIdentificationService{}, identifyClaims)
+
+	_, err := client.IdentifyScan(context.Background(), &pb.IdentifyScanRequest{
+		DeviceId: "app-install-1",
+		Beacons: []*pb.BeaconReading{
+			{Uuid: "550e8400-e29b-41d4-a716-446655440000", Major: 100, Minor: 3, Rssi: -62},
+			{Uuid: "660e8400-e29b-41d4-a716-446655440000", Major: 200, Minor: 1, Rssi: -80},
+		},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED for a beacon of another store")
+}
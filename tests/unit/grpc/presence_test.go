@@ -0,0 +1,103 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	pb "github.com/sukryu/customer-id.git/proto"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockPresenceService struct {
+	occupants []ports.Occupant
+	occupancy *services.Occupancy
+	err       error
+	storeID   string
+	location  string
+}
+
+func (s *mockPresenceService) ListOccupants(ctx context.Context, storeID, location string) ([]ports.Occupant, error) {
+	s.storeID, s.location = storeID, location
+	return s.occupants, s.err
+}
+
+func (s *mockPresenceService) GetOccupancy(ctx context.Context, storeID string) (*services.Occupancy, error) {
+	s.storeID = storeID
+	return s.occupancy, s.err
+}
+
+var readClaims = &auth.Claims{Scopes: []string{auth.ScopeReadCustomers}, Stores: []string{"store100"}}
+
+func TestListOccupants(t *testing.T) {
+	since := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	presence := &mockPresenceService{occupants: []ports.Occupant{{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Location:   testutil.MustLocation(t, "Table 5", entities.LocationTypeTable),
+		Since:      since,
+		LastSeenAt: since.Add(40 * time.Minute),
+	}}}
+	client := dial(t, &mockIdentificationService{}, presence, readClaims)
+
+	resp, err := client.ListOccupants(context.Background(), &pb.ListOccupantsRequest{StoreId: "store100", Location: "Table 5"})
+	if !assert.NoError(t, err, "Expected no error listing occupants") {
+		return
+	}
+	assert.Equal(t, "Table 5", presence.location, "Location filter mismatch")
+	if assert.Len(t, resp.GetOccupants(), 1) {
+		occupant := resp.GetOccupants()[0]
+		assert.Equal(t, "cust123", occupant.GetCustomerId())
+		assert.Equal(t, "table", occupant.GetLocationType())
+		assert.Equal(t, "2025-03-02T12:00:00Z", occupant.GetSince())
+		assert.Equal(t, "2025-03-02T12:40:00Z", occupant.GetLastSeenAt())
+	}
+}
+
+func TestGetOccupancy(t *testing.T) {
+	presence := &mockPresenceService{occupancy: &services.Occupancy{
+		StoreID: "store100",
+		Total:   3,
+		Zones:   []services.ZoneOccupancy{{Zone: "Hall", Count: 2}, {Zone: "Terrace", Floor: 1}},
+		Unzoned: 1,
+	}}
+	client := dial(t, &mockIdentificationService{}, presence, readClaims)
+
+	resp, err := client.GetOccupancy(context.Background(), &pb.GetOccupancyRequest{StoreId: "store100"})
+	if !assert.NoError(t, err, "Expected no error counting occupancy") {
+		return
+	}
+	assert.Equal(t, int32(3), resp.GetTotal())
+	assert.Equal(t, int32(1), resp.GetUnzoned())
+	if assert.Len(t, resp.GetZones(), 2, "Empty zones are reported too") {
+		assert.Equal(t, "Hall", resp.GetZones()[0].GetZone())
+		assert.Equal(t, int32(2), resp.GetZones()[0].GetCount())
+		assert.Equal(t, int32(1), resp.GetZones()[1].GetFloor())
+	}
+}
+
+func TestPresenceErrors(t *testing.T) {
+	presence := &mockPresenceService{}
+	client := dial(t, &mockIdentificationService{}, presence, readClaims)
+
+	_, err := client.ListOccupants(context.Background(), &pb.ListOccupantsRequest{StoreId: "store200"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED for another store")
+	_, err = client.GetOccupancy(context.Background(), &pb.GetOccupancyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected INVALID_ARGUMENT without a store")
+
+	identifyOnly := dial(t, &mockIdentificationService{}, presence, identifyClaims)
+	_, err = identifyOnly.GetOccupancy(context.Background(), &pb.GetOccupancyRequest{StoreId: "store100"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Expected PERMISSION_DENIED without the read:customers scope")
+
+	presence.err = domainerr.Errorf(domainerr.ErrStoreNotFound, "store store100 not found")
+	_, err = client.GetOccupancy(context.Background(), &pb.GetOccupancyRequest{StoreId: "store100"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Expected NOT_FOUND for unknown stores")
+}
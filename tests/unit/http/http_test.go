@@ -0,0 +1,359 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/internal/interfaces/apierr"
+	httpapi "github.com/sukryu/customer-id.git/internal/interfaces/http"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+	"go.uber.org/zap/zaptest"
+)
+
+type mockIdentificationService struct {
+	identity *aggregates.CustomerIdentity
+	outcome  services.Outcome // Outcome of successful calls; identified if empty
+	err      error
+	delay    time.Duration
+	deviceID entities.DeviceID
+	beacon   entities.BeaconData
+	qr       entities.QRData
+	scan     entities.BeaconScan
+}
+
+func (s *mockIdentificationService) IdentifyScan(ctx context.Context, deviceID entities.DeviceID, scan entities.BeaconScan) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.scan = scan
+	return s.result()
+}
+
+func (s *mockIdentificationService) IdentifyByQR(ctx context.Context, deviceID entities.DeviceID, qr entities.QRData) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.qr = qr
+	return s.result()
+}
+
+func (s *mockIdentificationService) IdentifyCustomer(ctx context.Context, deviceID entities.DeviceID, beaconData entities.BeaconData) (*services.Identification, error) {
+	s.deviceID = deviceID
+	s.beacon = beaconData
+	select {
+	case <-time.After(s.delay):
+		return s.result()
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to retrieve beacon: %w", ctx.Err())
+	}
+}
+
+func (s *mockIdentificationService) result() (*services.Identification, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	outcome := s.outcome
+	if outcome == "" {
+		outcome = services.OutcomeIdentified
+	}
+	return &services.Identification{Identity: s.identity, Outcome: outcome}, nil
+}
+
+type mockBeaconRepo struct {
+	beacons map[string]*entities.Beacon
+	err     error
+}
+
+func (r *mockBeaconRepo) FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error) {
+	return r.beacons[uuid], r.err
+}
+
+func newAuthorizer(t *testing.T) *auth.Authorizer {
+	authorizer, err := auth.NewAuthorizer(&mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+			"660e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "660e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store200",
+				Major:    200,
+				Minor:    1,
+				Location: testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance),
+				Status:   entities.StatusActive,
+			},
+		},
+	})
+	assert.NoError(t, err, "Failed to create authorizer")
+	return authorizer
+}
+
+var identifyClaims = &auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store100"}}
+
+func newQRCodec(t *testing.T) *auth.QRCodec {
+	codec, err := auth.NewQRCodec([]byte("test-qr-signing-key-0123456789abcdef"))
+	assert.NoError(t, err, "Failed to create QR codec")
+	return codec
+}
+
+// qrBody returns an identify/qr request body with a payload signed by newQRCodec for storeID.
+func qrBody(t *testing.T, storeID string) string {
+	qr, err := entities.NewQRData(storeID, testutil.MustLocation(t, "Table 7", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+	payload, err := newQRCodec(t).Encode(qr)
+	assert.NoError(t, err)
+	return fmt.Sprintf(`{"device_id":"app-install-1","payload":%q}`, payload)
+}
+
+const validBody = `{"device_id":"app-install-1","uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":-50,"timestamp":"2025-03-02T12:00:00Z"}`
+
+// serve sends body to POST /customer-id/identify on a handler backed by svc,
+// authenticated as a client holding identifyClaims.
+func serve(t *testing.T, svc services.IdentificationService, timeout time.Duration, body string) *httptest.ResponseRecorder {
+	return serveAs(t, identifyClaims, svc, timeout, body)
+}
+
+func serveAs(t *testing.T, claims *auth.Claims, svc services.IdentificationService, timeout time.Duration, body string) *httptest.ResponseRecorder {
+	return post(t, claims, svc, newQRCodec(t), timeout, "/customer-id/identify", body)
+}
+
+// post sends body to path on a handler backed by svc and qr, authenticated as a client holding claims.
+func post(t *testing.T, claims *auth.Claims, svc services.IdentificationService, qr *auth.QRCodec, timeout time.Duration, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return send(t, claims, svc, &mockPresenceService{}, qr, timeout, req)
+}
+
+// send serves req on a handler backed by svc, presence and qr, authenticated as a client holding claims.
+func send(t *testing.T, claims *auth.Claims, svc services.IdentificationService, presence services.PresenceService, qr *auth.QRCodec, timeout time.Duration, req *http.Request) *httptest.ResponseRecorder {
+	handler, err := httpapi.NewHandler(svc, presence, newAuthorizer(t), qr, timeout, zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create HTTP handler")
+
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	req = req.WithContext(auth.NewContext(req.Context(), claims))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) apierr.ErrorDetail {
+	var resp apierr.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp), "Failed to decode error envelope")
+	return resp.Error
+}
+
+func TestIdentify(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.95,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	rec := serve(t, svc, time.Second, validBody)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.Equal(t, "app-install-1", svc.deviceID.String(), "DeviceID mismatch")
+	assert.Equal(t, time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC), svc.beacon.DetectedAt(), "Expected the request timestamp as detection time")
+	var resp httpapi.IdentifyResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "cust123", resp.CustomerID, "CustomerID mismatch")
+	assert.Equal(t, "Table 3", resp.Location, "Location mismatch")
+	assert.Equal(t, "table", resp.LocationType, "Location type mismatch")
+	assert.Equal(t, float32(0.95), resp.Confidence, "Confidence mismatch")
+	assert.False(t, resp.AlreadyIdentified, "Expected a new identification")
+}
+
+func TestIdentifyAlreadyIdentified(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.9,
+		DetectedAt: time.Now().UTC().Add(-30 * time.Second),
+	}
+	svc := &mockIdentificationService{identity: identity, outcome: services.OutcomeAlreadyIdentified}
+	rec := serve(t, svc, time.Second, validBody)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK for already identified customers")
+	var resp httpapi.IdentifyResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.True(t, resp.AlreadyIdentified, "Expected already_identified to be set")
+	assert.Equal(t, "cust123", resp.CustomerID, "CustomerID mismatch")
+}
+
+func TestIdentifyInvalidBody(t *testing.T) {
+	rec := serve(t, &mockIdentificationService{}, time.Second, `{"uuid":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for malformed JSON")
+	assert.Equal(t, 3, decodeError(t, rec).Code, "Expected INVALID_ARGUMENT code in envelope")
+}
+
+func TestIdentifyInvalidBeaconData(t *testing.T) {
+	body := `{"device_id":"app-install-1","uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":10}`
+	rec := serve(t, &mockIdentificationService{}, time.Second, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for out-of-range RSSI")
+	assert.Contains(t, decodeError(t, rec).Message, "rssi must be between -100 and 0")
+}
+
+func TestIdentifyInvalidTimestamp(t *testing.T) {
+	body := `{"device_id":"app-install-1","uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":-50,"timestamp":"yesterday"}`
+	rec := serve(t, &mockIdentificationService{}, time.Second, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for malformed timestamp")
+	assert.Contains(t, decodeError(t, rec).Message, "invalid timestamp")
+}
+
+func TestIdentifyMissingDeviceID(t *testing.T) {
+	body := `{"uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":-50}`
+	rec := serve(t, &mockIdentificationService{}, time.Second, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 without a device ID")
+	assert.Contains(t, decodeError(t, rec).Message, "device_id is required")
+}
+
+func TestIdentifyNotFound(t *testing.T) {
+	svcErr := fmt.Errorf("%w: beacon not found", services.ErrCustomerNotIdentified)
+	rec := serve(t, &mockIdentificationService{err: svcErr}, time.Second, validBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Expected 404 when identification is rejected")
+	assert.Equal(t, 5, decodeError(t, rec).Code, "Expected NOT_FOUND code in envelope")
+}
+
+func TestIdentifyInternalError(t *testing.T) {
+	rec := serve(t, &mockIdentificationService{err: fmt.Errorf("connection refused")}, time.Second, validBody)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected 500 for infrastructure failures")
+	assert.NotContains(t, decodeError(t, rec).Message, "connection refused", "Internal details must not leak")
+}
+
+func TestIdentifyStorageUnavailable(t *testing.T) {
+	err := domainerr.Errorf(domainerr.ErrStorage, "failed to retrieve beacon: connection refused")
+	rec := serve(t, &mockIdentificationService{err: err}, time.Second, validBody)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "Expected 503 for storage failures")
+	assert.NotContains(t, decodeError(t, rec).Message, "connection refused", "Storage details must not leak")
+}
+
+func TestIdentifyAuthorizationUnavailable(t *testing.T) {
+	authorizer, err := auth.NewAuthorizer(&mockBeaconRepo{err: fmt.Errorf("connection refused")})
+	assert.NoError(t, err, "Failed to create authorizer")
+	handler, err := httpapi.NewHandler(&mockIdentificationService{}, &mockPresenceService{}, authorizer, newQRCodec(t), time.Second, zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create HTTP handler")
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/customer-id/identify", strings.NewReader(validBody))
+	req = req.WithContext(auth.NewContext(req.Context(), identifyClaims))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "Expected 503 when the beacon store cannot be resolved")
+	assert.NotContains(t, decodeError(t, rec).Message, "connection refused", "Storage details must not leak")
+}
+
+func TestIdentifyBeaconInactive(t *testing.T) {
+	err := domainerr.Errorf(domainerr.ErrBeaconInactive, "%w: beacon is not active", services.ErrCustomerNotIdentified)
+	rec := serve(t, &mockIdentificationService{err: err}, time.Second, validBody)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for an inactive beacon")
+	assert.Equal(t, 9, decodeError(t, rec).Code, "Expected FAILED_PRECONDITION in the envelope")
+}
+
+func TestIdentifyTimeout(t *testing.T) {
+	svc := &mockIdentificationService{delay: 200 * time.Millisecond}
+	rec := serve(t, svc, 20*time.Millisecond, validBody)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code, "Expected 504 when the request deadline expires")
+}
+
+func TestIdentifyPermissionDenied(t *testing.T) {
+	otherStore := &auth.Claims{Scopes: []string{auth.ScopeIdentify}, Stores: []string{"store200"}}
+	rec := serveAs(t, otherStore, &mockIdentificationService{}, time.Second, validBody)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "Expected 403 for a beacon of another store")
+	assert.Equal(t, 7, decodeError(t, rec).Code, "Expected PERMISSION_DENIED code in envelope")
+}
+
+func TestIdentifyQR(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Source:     aggregates.SourceQR,
+		Location:   testutil.MustLocation(t, "Table 7", entities.LocationTypeTable),
+		Confidence: 1.0,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	rec := post(t, identifyClaims, svc, newQRCodec(t), time.Second, "/customer-id/identify/qr", qrBody(t, "store100"))
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.Equal(t, "store100", svc.qr.StoreID(), "StoreID mismatch")
+	var resp httpapi.IdentifyResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "Table 7", resp.Location, "Location mismatch")
+	assert.Equal(t, float32(1.0), resp.Confidence, "Confidence mismatch")
+}
+
+func TestIdentifyQRInvalidPayload(t *testing.T) {
+	body := `{"device_id":"app-install-1","payload":"TSQR1.e30.c2lnbmF0dXJl"}`
+	rec := post(t, identifyClaims, &mockIdentificationService{}, newQRCodec(t), time.Second, "/customer-id/identify/qr", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for a payload with a wrong signature")
+	assert.Contains(t, decodeError(t, rec).Message, "invalid QR code")
+}
+
+func TestIdentifyQRPermissionDenied(t *testing.T) {
+	rec := post(t, identifyClaims, &mockIdentificationService{}, newQRCodec(t), time.Second, "/customer-id/identify/qr", qrBody(t, "store200"))
+	assert.Equal(t, http.StatusForbidden, rec.Code, "Expected 403 for another store's code")
+}
+
+func TestIdentifyQRDisabled(t *testing.T) {
+	rec := post(t, identifyClaims, &mockIdentificationService{}, nil, time.Second, "/customer-id/identify/qr", qrBody(t, "store100"))
+	assert.Equal(t, http.StatusNotImplemented, rec.Code, "Expected 501 without a QR signing key")
+}
+
+const scanBody = `{"device_id":"app-install-1","beacons":[` +
+	`{"uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":-62},` +
+	`{"uuid":"770e8400-e29b-41d4-a716-446655440000","major":100,"minor":4,"rssi":-75}]}`
+
+func TestIdentifyScan(t *testing.T) {
+	identity := &aggregates.CustomerIdentity{
+		CustomerID: "cust123",
+		BeaconID:   "550e8400-e29b-41d4-a716-446655440000",
+		Location:   testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+		Confidence: 0.95,
+		DetectedAt: time.Now().UTC(),
+	}
+	svc := &mockIdentificationService{identity: identity}
+	rec := post(t, identifyClaims, svc, nil, time.Second, "/customer-id/identify/scan", scanBody)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.Len(t, svc.scan.Readings(), 2, "Every beacon of the scan is passed on")
+	var resp httpapi.IdentifyResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "Table 3", resp.Location, "Location mismatch")
+}
+
+func TestIdentifyScanInvalidBeaconData(t *testing.T) {
+	body := `{"device_id":"app-install-1","beacons":[{"uuid":"550e8400-e29b-41d4-a716-446655440000","rssi":-62},{"uuid":"bad","rssi":-70}]}`
+	rec := post(t, identifyClaims, &mockIdentificationService{}, nil, time.Second, "/customer-id/identify/scan", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for an invalid beacon")
+	assert.Contains(t, decodeError(t, rec).Message, "index 1")
+
+	rec = post(t, identifyClaims, &mockIdentificationService{}, nil, time.Second, "/customer-id/identify/scan", `{"device_id":"app-install-1","beacons":[]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected 400 for an empty scan")
+}
+
+func TestIdentifyScanPermissionDenied(t *testing.T) {
+	body := `{"device_id":"app-install-1","beacons":[` +
+		`{"uuid":"550e8400-e29b-41d4-a716-446655440000","major":100,"minor":3,"rssi":-62},` +
+		`{"uuid":"660e8400-e29b-41d4-a716-446655440000","major":200,"minor":1,"rssi":-80}]}`
+	rec := post(t, identifyClaims, &mockIdentificationService{}, nil, time.Second, "/customer-id/identify/scan", body)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "Expected 403 for a beacon of another store")
+}
@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/auth"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	httpapi "github.com/sukryu/customer-id.git/internal/interfaces/http"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+type mockPresenceService struct {
+	occupants []ports.Occupant
+	occupancy *services.Occupancy
+	err       error
+	storeID   string
+	location  string
+}
+
+func (s *mockPresenceService) ListOccupants(ctx context.Context, storeID, location string) ([]ports.Occupant, error) {
+	s.storeID, s.location = storeID, location
+	return s.occupants, s.err
+}
+
+func (s *mockPresenceService) GetOccupancy(ctx context.Context, storeID string) (*services.Occupancy, error) {
+	s.storeID = storeID
+	return s.occupancy, s.err
+}
+
+var readClaims = &auth.Claims{Scopes: []string{auth.ScopeReadCustomers}, Stores: []string{"store100"}}
+
+// get sends GET path to a handler backed by presence, authenticated as a client holding claims.
+func get(t *testing.T, claims *auth.Claims, presence services.PresenceService, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	return send(t, claims, &mockIdentificationService{}, presence, newQRCodec(t), time.Second, req)
+}
+
+func TestListOccupants(t *testing.T) {
+	since := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	presence := &mockPresenceService{occupants: []ports.Occupant{{
+		CustomerID: "cust123",
+		StoreID:    "store100",
+		Location:   testutil.MustLocation(t, "Table 5", entities.LocationTypeTable),
+		Since:      since,
+		LastSeenAt: since.Add(40 * time.Minute),
+	}}}
+	rec := get(t, readClaims, presence, "/customer-id/stores/store100/occupants?location=Table%205")
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.Equal(t, "store100", presence.storeID, "Store mismatch")
+	assert.Equal(t, "Table 5", presence.location, "Location filter mismatch")
+	var resp httpapi.ListOccupantsResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	if assert.Len(t, resp.Occupants, 1) {
+		assert.Equal(t, "cust123", resp.Occupants[0].CustomerID)
+		assert.Equal(t, "table", resp.Occupants[0].LocationType)
+		assert.Equal(t, 40*time.Minute, resp.Occupants[0].LastSeenAt.Sub(resp.Occupants[0].Since))
+	}
+}
+
+func TestListOccupantsEmpty(t *testing.T) {
+	rec := get(t, readClaims, &mockPresenceService{}, "/customer-id/stores/store100/occupants")
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.JSONEq(t, `{"occupants":[]}`, rec.Body.String(), "Empty stores list no occupants")
+}
+
+func TestGetOccupancy(t *testing.T) {
+	presence := &mockPresenceService{occupancy: &services.Occupancy{
+		StoreID: "store100",
+		Total:   3,
+		Zones:   []services.ZoneOccupancy{{Zone: "Hall", Count: 2}, {Zone: "Terrace", Floor: 1}},
+		Unzoned: 1,
+	}}
+	rec := get(t, readClaims, presence, "/customer-id/stores/store100/occupancy")
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected 200 OK")
+	assert.JSONEq(t, `{"store_id":"store100","total":3,"zones":[{"zone":"Hall","floor":0,"count":2},{"zone":"Terrace","floor":1,"count":0}],"unzoned":1}`, rec.Body.String())
+}
+
+func TestPresenceErrors(t *testing.T) {
+	presence := &mockPresenceService{}
+	rec := get(t, readClaims, presence, "/customer-id/stores/store200/occupancy")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "Expected 403 for another store")
+
+	rec = get(t, identifyClaims, presence, "/customer-id/stores/store100/occupants")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "Expected 403 without the read:customers scope")
+
+	presence.err = domainerr.Errorf(domainerr.ErrStoreNotFound, "store store100 not found")
+	rec = get(t, readClaims, presence, "/customer-id/stores/store100/occupancy")
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Expected 404 for unknown stores")
+
+	presence.err = domainerr.Errorf(domainerr.ErrStorage, "failed to list occupants of store store100")
+	rec = get(t, readClaims, presence, "/customer-id/stores/store100/occupants")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "Expected 503 for storage failures")
+}
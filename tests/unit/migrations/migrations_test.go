@@ -0,0 +1,72 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/db"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := db.Migrations()
+	if !assert.NoError(t, err, "Embedded migrations must be consistent") {
+		return
+	}
+	if !assert.NotEmpty(t, migrations) {
+		return
+	}
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "Versions must be sequential")
+		assert.NotEmpty(t, m.Up, "Migration %d needs up SQL", m.Version)
+		assert.NotEmpty(t, m.Down, "Migration %d needs down SQL", m.Version)
+	}
+}
+
+func TestParseMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_add_index.up.sql":   {Data: []byte("CREATE INDEX ...")},
+		"m/0002_add_index.down.sql": {Data: []byte("DROP INDEX ...")},
+		"m/0001_init.up.sql":        {Data: []byte("CREATE TABLE ...")},
+		"m/0001_init.down.sql":      {Data: []byte("DROP TABLE ...")},
+	}
+	migrations, err := db.ParseMigrations(fsys, "m")
+	if !assert.NoError(t, err) || !assert.Len(t, migrations, 2) {
+		return
+	}
+	assert.Equal(t, "init", migrations[0].Name, "Migrations should be ordered by version")
+	assert.Equal(t, "CREATE INDEX ...", migrations[1].Up)
+	assert.Equal(t, "DROP INDEX ...", migrations[1].Down)
+}
+
+func TestParseMigrationsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{
+			"m/0001_init.up.sql": {Data: []byte("CREATE TABLE ...")},
+		}},
+		{"gap in versions", fstest.MapFS{
+			"m/0001_init.up.sql":    {Data: []byte("CREATE TABLE ...")},
+			"m/0001_init.down.sql":  {Data: []byte("DROP TABLE ...")},
+			"m/0003_later.up.sql":   {Data: []byte("CREATE INDEX ...")},
+			"m/0003_later.down.sql": {Data: []byte("DROP INDEX ...")},
+		}},
+		{"conflicting names", fstest.MapFS{
+			"m/0001_init.up.sql":    {Data: []byte("CREATE TABLE ...")},
+			"m/0001_other.down.sql": {Data: []byte("DROP TABLE ...")},
+		}},
+		{"unexpected file", fstest.MapFS{
+			"m/README.md": {Data: []byte("notes")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := db.ParseMigrations(tt.files, "m")
+			assert.Error(t, err, "Expected error for %s", tt.name)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/outbox"
+	"go.uber.org/zap/zaptest"
+)
+
+// mockOutbox keeps pending messages in memory and mimics the relay contract of
+// the Postgres outbox: in-order delivery that stops at the first failure.
+type mockOutbox struct {
+	mu      sync.Mutex
+	pending []ports.OutboxMessage
+	sent    []ports.OutboxMessage
+}
+
+func (o *mockOutbox) RelayOutbox(ctx context.Context, limit int, deliver func(ctx context.Context, msg ports.OutboxMessage) error) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	sent := 0
+	for len(o.pending) > 0 && sent < limit {
+		msg := o.pending[0]
+		if err := deliver(ctx, msg); err != nil {
+			o.pending[0].Attempts++
+			return sent, err
+		}
+		o.pending = o.pending[1:]
+		o.sent = append(o.sent, msg)
+		sent++
+	}
+	return sent, nil
+}
+
+func (o *mockOutbox) sentCount() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.sent)
+}
+
+type mockPublisher struct {
+	keys    []string
+	failFor map[string]bool
+}
+
+func (p *mockPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	if p.failFor[key] {
+		return fmt.Errorf("broker unavailable")
+	}
+	p.keys = append(p.keys, key)
+	return nil
+}
+
+func pendingMessages(keys ...string) []ports.OutboxMessage {
+	messages := make([]ports.OutboxMessage, len(keys))
+	for i, key := range keys {
+		messages[i] = ports.OutboxMessage{
+			ID:        int64(i + 1),
+			EventID:   fmt.Sprintf("evt-%d", i+1),
+			EventType: "CustomerIdentified",
+			Key:       key,
+			Payload:   []byte(`{}`),
+			CreatedAt: time.Now().UTC(),
+		}
+	}
+	return messages
+}
+
+func TestRelayDrain(t *testing.T) {
+	store := &mockOutbox{pending: pendingMessages("cust1", "cust2", "cust1", "cust3", "cust2")}
+	publisher := &mockPublisher{}
+
+	relay, err := outbox.NewRelay(store, publisher, time.Second, 2, zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create relay")
+
+	sent := relay.Drain(context.Background())
+	assert.Equal(t, 5, sent, "Expected all pending messages to be relayed across batches")
+	assert.Equal(t, []string{"cust1", "cust2", "cust1", "cust3", "cust2"}, publisher.keys, "Messages must be published in outbox order")
+	assert.Empty(t, store.pending, "Outbox should be empty")
+}
+
+func TestRelayDrainStopsAtFailure(t *testing.T) {
+	store := &mockOutbox{pending: pendingMessages("cust1", "cust2", "cust3")}
+	publisher := &mockPublisher{failFor: map[string]bool{"cust2": true}}
+
+	relay, err := outbox.NewRelay(store, publisher, time.Second, 10, zaptest.NewLogger(t))
+	assert.NoError(t, err)
+
+	sent := relay.Drain(context.Background())
+	assert.Equal(t, 1, sent, "Expected relaying to stop at the failed message")
+	assert.Equal(t, []string{"cust1"}, publisher.keys)
+	if assert.Len(t, store.pending, 2, "Failed and later messages must stay pending") {
+		assert.Equal(t, 1, store.pending[0].Attempts, "Failed attempt should be recorded")
+	}
+
+	// Once the broker recovers the remaining messages are delivered in order
+	publisher.failFor = nil
+	sent = relay.Drain(context.Background())
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"cust1", "cust2", "cust3"}, publisher.keys)
+}
+
+func TestRelayRunStopsOnCancel(t *testing.T) {
+	store := &mockOutbox{pending: pendingMessages("cust1")}
+	publisher := &mockPublisher{}
+
+	relay, err := outbox.NewRelay(store, publisher, 10*time.Millisecond, 10, zaptest.NewLogger(t))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return store.sentCount() == 1 }, time.Second, 5*time.Millisecond, "Expected pending message to be relayed")
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Relay did not stop after cancellation")
+	}
+}
+
+func TestNewRelayValidation(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	_, err := outbox.NewRelay(nil, &mockPublisher{}, time.Second, 10, logger)
+	assert.Error(t, err, "Expected error for missing outbox")
+	_, err = outbox.NewRelay(&mockOutbox{}, nil, time.Second, 10, logger)
+	assert.Error(t, err, "Expected error for missing publisher")
+	_, err = outbox.NewRelay(&mockOutbox{}, &mockPublisher{}, 0, 10, logger)
+	assert.Error(t, err, "Expected error for non-positive interval")
+	_, err = outbox.NewRelay(&mockOutbox{}, &mockPublisher{}, time.Second, 0, logger)
+	assert.Error(t, err, "Expected error for non-positive batch size")
+}
@@ -0,0 +1,138 @@
+package partitions_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/db"
+)
+
+func TestPartitionFor(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	monthly := db.PartitionFor(db.IdentitiesTable, db.GranularityMonthly, at)
+	assert.Equal(t, "customer_identities_2026_10", monthly.Name, "Monthly name mismatch")
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), monthly.From)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), monthly.To)
+
+	daily := db.PartitionFor(db.IdentitiesTable, db.GranularityDaily, at)
+	assert.Equal(t, "customer_identities_2026_10_16", daily.Name, "Daily name mismatch")
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), daily.To)
+
+	yearly := db.PartitionFor(db.IdentitiesTable, db.GranularityYearly, at)
+	assert.Equal(t, "customer_identities_2026", yearly.Name, "Yearly name mismatch")
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), yearly.To)
+
+	// Times in other zones are assigned by their UTC instant
+	seoul := time.FixedZone("KST", 9*60*60)
+	assert.Equal(t, "customer_identities_2026_09",
+		db.PartitionFor(db.IdentitiesTable, db.GranularityMonthly, time.Date(2026, 10, 1, 8, 0, 0, 0, seoul)).Name)
+}
+
+func TestParsePartition(t *testing.T) {
+	legacy, ok := db.ParsePartition(db.IdentitiesTable, "customer_identities_2025")
+	if assert.True(t, ok, "Legacy yearly partition should be recognised") {
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), legacy.From)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), legacy.To)
+	}
+
+	monthly, ok := db.ParsePartition(db.IdentitiesTable, "customer_identities_2026_02")
+	if assert.True(t, ok) {
+		assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), monthly.To)
+	}
+
+	_, ok = db.ParsePartition(db.IdentitiesTable, "customer_identities_default")
+	assert.False(t, ok, "Names outside the scheme must be ignored")
+	_, ok = db.ParsePartition(db.IdentitiesTable, "outbox_2026_02")
+	assert.False(t, ok, "Partitions of other tables must be ignored")
+}
+
+func TestPlanPartitions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	policy := db.PartitionPolicy{Granularity: db.GranularityMonthly, Premake: 2}
+
+	create, expire := db.PlanPartitions(db.IdentitiesTable,
+		[]string{"customer_identities_2025", "customer_identities_2026_10"}, now, policy)
+	var names []string
+	for _, p := range create {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"customer_identities_2026_11", "customer_identities_2026_12"}, names,
+		"Expected only the missing future partitions")
+	assert.Empty(t, expire, "Nothing expires without retention")
+
+	// Periods covered by a partition of another granularity are not recreated
+	create, _ = db.PlanPartitions(db.IdentitiesTable, []string{"customer_identities_2026"}, now, policy)
+	if assert.Len(t, create, 0, "The yearly partition covers October to December") {
+		create, _ = db.PlanPartitions(db.IdentitiesTable, []string{"customer_identities_2026"}, now,
+			db.PartitionPolicy{Granularity: db.GranularityMonthly, Premake: 3})
+		if assert.Len(t, create, 1) {
+			assert.Equal(t, "customer_identities_2027_01", create[0].Name)
+		}
+	}
+}
+
+func TestPlanPartitionsCoarserGranularity(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	policy := db.PartitionPolicy{Granularity: db.GranularityMonthly, Premake: 1}
+
+	// Daily partitions left from before switching to monthly cover only part of October
+	existing := []string{"customer_identities_2026_10_05", "customer_identities_2026_10_16"}
+	create, _ := db.PlanPartitions(db.IdentitiesTable, existing, now, policy)
+	if !assert.Len(t, create, 30, "Expected the 29 uncovered days of October and November") {
+		return
+	}
+	assert.Equal(t, "customer_identities_2026_10_01", create[0].Name)
+	assert.Equal(t, "customer_identities_2026_10_17", create[14].Name)
+	assert.Equal(t, "customer_identities_2026_11", create[29].Name)
+
+	// Together with the existing partitions, October and November are covered exactly once
+	ranges := create
+	for _, name := range existing {
+		p, ok := db.ParsePartition(db.IdentitiesTable, name)
+		assert.True(t, ok)
+		ranges = append(ranges, p)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].From.Before(ranges[j].From) })
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for _, p := range ranges {
+		assert.Equal(t, from, p.From, "Gap or overlap before %s", p.Name)
+		from = p.To
+	}
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), from)
+
+	// Uncovered ranges are filled with the coarsest partitions that fit
+	create, _ = db.PlanPartitions(db.IdentitiesTable, []string{"customer_identities_2026_01_31"}, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		db.PartitionPolicy{Granularity: db.GranularityYearly})
+	var names []string
+	for _, p := range create {
+		names = append(names, p.Name)
+	}
+	assert.Contains(t, names, "customer_identities_2026_02")
+	assert.Contains(t, names, "customer_identities_2026_12")
+	assert.Len(t, names, 41, "Expected 30 days of January and 11 months")
+}
+
+func TestPlanPartitionsRetention(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	policy := db.PartitionPolicy{Granularity: db.GranularityMonthly, Premake: 0, Retention: 90 * 24 * time.Hour}
+
+	_, expire := db.PlanPartitions(db.IdentitiesTable, []string{
+		"customer_identities_2025",
+		"customer_identities_2026_06",
+		"customer_identities_2026_07",
+		"customer_identities_2026_08",
+		"customer_identities_2026_10",
+		"customer_identities_default",
+	}, now, policy)
+
+	var names []string
+	for _, p := range expire {
+		names = append(names, p.Name)
+	}
+	// The cutoff is 2026-07-18: July still holds rows inside the window
+	assert.Equal(t, []string{"customer_identities_2025", "customer_identities_2026_06"}, names,
+		"Expected only partitions entirely older than the retention window, oldest first")
+}
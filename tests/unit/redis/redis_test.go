@@ -2,6 +2,7 @@ package redis_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
 	"github.com/sukryu/customer-id.git/internal/infrastructure/redis"
+	"github.com/sukryu/customer-id.git/tests/testutil"
 )
 
 func TestCache(t *testing.T) {
@@ -20,8 +22,8 @@ func TestCache(t *testing.T) {
 	// Create test data
 	cust, _ := entities.NewCustomer("cust123", nil)
 	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
-	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, "Table 3", entities.StatusActive)
-	identity, _ := aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC())
+	beacon, _ := entities.NewBeacon("550e8400-e29b-41d4-a716-446655440000", "store100", 100, 3, testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), entities.StatusActive)
+	identity, _ := aggregates.NewCustomerIdentity(cust, beacon, 0.95, time.Now().UTC(), aggregates.DefaultIdentificationPolicy())
 
 	// Test SetCustomerIdentity
 	ctx := context.Background()
@@ -33,7 +35,7 @@ func TestCache(t *testing.T) {
 	assert.NoError(t, err, "Failed to get CustomerIdentity from Redis")
 	assert.NotNil(t, retrieved, "Retrieved identity should not be nil")
 	assert.Equal(t, "cust123", retrieved.GetCustomerID(), "CustomerID mismatch")
-	assert.Equal(t, "Table 3", retrieved.GetLocation(), "Location mismatch")
+	assert.Equal(t, "Table 3", retrieved.GetLocation().Name(), "Location mismatch")
 }
 
 func TestGetCustomerIdentityNotFound(t *testing.T) {
@@ -46,3 +48,104 @@ func TestGetCustomerIdentityNotFound(t *testing.T) {
 	assert.NoError(t, err, "Expected no error for cache miss")
 	assert.Nil(t, retrieved, "Expected nil for non-existent key")
 }
+
+func TestSignalSmoother(t *testing.T) {
+	cache, err := redis.NewCache("localhost:6379", "redisecret", 0)
+	assert.NoError(t, err)
+	defer cache.Close()
+	_, err = cache.SignalSmoother(0, time.Minute)
+	assert.Error(t, err, "Expected error for an empty window")
+	smoother, err := cache.SignalSmoother(3, time.Minute)
+	assert.NoError(t, err, "Failed to create signal smoother")
+
+	ctx := context.Background()
+	deviceID, err := entities.NewDeviceID("app-install-" + time.Now().Format("150405.000000"))
+	assert.NoError(t, err)
+	beaconID := "550e8400-e29b-41d4-a716-446655440000"
+	now := time.Now().UTC()
+
+	// Readings older than maxAge are ignored
+	_, err = smoother.Smooth(ctx, deviceID, beaconID, -90, now.Add(-2*time.Minute))
+	assert.NoError(t, err)
+
+	signal, err := smoother.Smooth(ctx, deviceID, beaconID, -60, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signal.Samples())
+	assert.Equal(t, -60.0, signal.RSSI())
+
+	for _, rssi := range []int32{-70, -65, -62} {
+		signal, err = smoother.Smooth(ctx, deviceID, beaconID, rssi, now)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, signal.Samples(), "The window keeps the latest readings")
+	assert.InDelta(t, -65.67, signal.RSSI(), 0.01)
+	assert.Greater(t, signal.Variance(), 0.0)
+
+	// Placements are remembered per device
+	nearest, err := smoother.FindNearestBeacon(ctx, deviceID)
+	assert.NoError(t, err)
+	assert.Empty(t, nearest, "Unknown placement")
+	assert.NoError(t, smoother.SaveNearestBeacon(ctx, deviceID, beaconID))
+	nearest, err = smoother.FindNearestBeacon(ctx, deviceID)
+	assert.NoError(t, err)
+	assert.Equal(t, beaconID, nearest)
+}
+
+func TestPresenceIndex(t *testing.T) {
+	cache, err := redis.NewCache("localhost:6379", "redisecret", 0)
+	if !assert.NoError(t, err, "Failed to create Redis cache") {
+		return
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+	store, otherStore := fmt.Sprintf("store-presence-%d", suffix), fmt.Sprintf("store-other-%d", suffix)
+	start := time.Now().UTC().Truncate(time.Millisecond)
+	identify := func(customerID, storeID string, location entities.Location, at time.Duration) {
+		t.Helper()
+		identity := &aggregates.CustomerIdentity{
+			CustomerID: customerID,
+			StoreID:    storeID,
+			Source:     aggregates.SourceQR,
+			Location:   location,
+			Confidence: 1.0,
+			DetectedAt: start.Add(at),
+		}
+		assert.NoError(t, cache.UpdatePresence(ctx, identity, 30*time.Minute), "Failed to update presence")
+	}
+	entrance := testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance)
+	table5 := testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)
+	alice, bob := fmt.Sprintf("alice-%d", suffix), fmt.Sprintf("bob-%d", suffix)
+
+	identify(alice, store, entrance, 0)
+	identify(bob, store, table5, time.Minute)
+	identify(alice, store, table5, 2*time.Minute)
+	identify(alice, store, entities.Location{}, 3*time.Minute)
+	identify(alice, store, entrance, time.Minute) // Late, ignored
+
+	occupants, err := cache.ListOccupants(ctx, store, "table 5", start.Add(5*time.Minute))
+	assert.NoError(t, err, "Failed to list occupants")
+	if assert.Len(t, occupants, 2, "Both customers are at table 5") {
+		assert.Equal(t, bob, occupants[0].CustomerID, "Longest present first")
+		assert.Equal(t, alice, occupants[1].CustomerID)
+		assert.Equal(t, start.Add(2*time.Minute), occupants[1].Since, "Unknown locations keep the customer where they were")
+		assert.Equal(t, start.Add(3*time.Minute), occupants[1].LastSeenAt)
+	}
+	occupants, err = cache.ListOccupants(ctx, store, "Entrance", start.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, occupants, "Customers who moved on leave their previous location")
+
+	// Moving to another store leaves the first one
+	identify(bob, otherStore, entrance, 4*time.Minute)
+	occupants, err = cache.ListOccupants(ctx, store, "", start.Add(5*time.Minute))
+	assert.NoError(t, err)
+	if assert.Len(t, occupants, 1) {
+		assert.Equal(t, alice, occupants[0].CustomerID)
+	}
+
+	// Customers not identified for the timeout are no longer present
+	occupants, err = cache.ListOccupants(ctx, store, "", start.Add(34*time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, occupants, "Expected presence to expire")
+}
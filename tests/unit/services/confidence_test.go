@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func TestLinearEstimator(t *testing.T) {
+	estimator := services.LinearEstimator{}
+	assert.Equal(t, float32(0.0), estimator.Estimate(nil, entities.SingleReading(-100)))
+	assert.Equal(t, float32(0.5), estimator.Estimate(nil, entities.SingleReading(-50)))
+	assert.Equal(t, float32(1.0), estimator.Estimate(nil, entities.SingleReading(0)))
+}
+
+func TestPathLossEstimator(t *testing.T) {
+	_, err := services.NewPathLossEstimator(0, 2, 10)
+	assert.Error(t, err, "Exponent must be positive")
+	_, err = services.NewPathLossEstimator(2, 10, 2)
+	assert.Error(t, err, "Near distance must be below far distance")
+
+	estimator, err := services.NewPathLossEstimator(2, 2, 10)
+	assert.NoError(t, err)
+
+	uncalibrated := &entities.Beacon{StoreID: "store100"}
+	assert.InDelta(t, 1.0, estimator.Distance(uncalibrated, float64(entities.DefaultTxPower)), 1e-9, "txPower is the RSSI at 1 meter")
+	assert.InDelta(t, 10.0, estimator.Distance(uncalibrated, -79), 1e-9, "20 dB of loss is 10 meters in free space")
+
+	assert.Equal(t, float32(1.0), estimator.Estimate(uncalibrated, entities.SingleReading(-60)), "Detections within the near distance are certain")
+	assert.Equal(t, float32(0.0), estimator.Estimate(uncalibrated, entities.SingleReading(-85)), "Detections beyond the far distance are discarded")
+	assert.InDelta(t, 0.81, estimator.Estimate(uncalibrated, entities.SingleReading(-70)), 0.01, "A typical table-side reading passes the threshold")
+
+	// A weaker beacon reaches the same distance with a lower RSSI
+	weak := &entities.Beacon{StoreID: "store100", TxPower: -75}
+	assert.Equal(t, float32(1.0), estimator.Estimate(weak, entities.SingleReading(-80)))
+}
+
+func TestStoreEstimator(t *testing.T) {
+	_, err := services.NewStoreEstimator(nil, nil)
+	assert.Error(t, err, "A default estimator is required")
+
+	pathLoss, err := services.NewPathLossEstimator(2, 2, 10)
+	assert.NoError(t, err)
+	estimator, err := services.NewStoreEstimator(pathLoss, map[string]services.ConfidenceEstimator{
+		"store100": services.LinearEstimator{},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, float32(0.3), estimator.Estimate(&entities.Beacon{StoreID: "store100"}, entities.SingleReading(-70)), "Override applies to its store")
+	assert.Equal(t, float32(0.3), estimator.Estimate(&entities.Beacon{StoreID: "STORE100"}, entities.SingleReading(-70)), "Store IDs match case-insensitively")
+	assert.InDelta(t, 0.81, estimator.Estimate(&entities.Beacon{StoreID: "store200"}, entities.SingleReading(-70)), 0.01, "Other stores use the default")
+}
+
+func TestStabilityEstimator(t *testing.T) {
+	_, err := services.NewStabilityEstimator(services.LinearEstimator{}, 0)
+	assert.Error(t, err, "Tolerance must be positive")
+
+	estimator, err := services.NewStabilityEstimator(services.LinearEstimator{}, 4)
+	assert.NoError(t, err)
+
+	stable, err := entities.NewSignalFromReadings([]int32{-12, -10, -8})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(0.9), estimator.Estimate(nil, stable), "Stable signals keep their confidence")
+
+	unstable, err := entities.NewSignalFromReadings([]int32{-16, -4}) // Standard deviation of 6 dBm
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.45, estimator.Estimate(nil, unstable), 1e-6, "Confidence is discounted beyond the tolerance")
+
+	erratic, err := entities.NewSignalFromReadings([]int32{-20, 0}) // Standard deviation of 10 dBm
+	assert.NoError(t, err)
+	assert.Equal(t, float32(0.0), estimator.Estimate(nil, erratic), "Signals beyond twice the tolerance are discarded")
+}
+
+func TestIdentifyCustomerConfidenceEstimator(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = "cust123"
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -70)
+	assert.NoError(t, err)
+
+	// The linear formula rejects a reading taken a few meters from the beacon
+	linear, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithConfidenceEstimator(services.LinearEstimator{}))
+	assert.NoError(t, err)
+	_, err = linear.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	assert.ErrorIs(t, err, services.ErrCustomerNotIdentified)
+
+	// The default path-loss model accepts it
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo)
+	assert.NoError(t, err)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.InDelta(t, 0.81, result.Identity.GetConfidence(), 0.01)
+
+	_, err = services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithConfidenceEstimator(nil))
+	assert.Error(t, err, "Estimator is required")
+}
+
+type mockSignalSmoother struct {
+	readings map[string][]int32
+}
+
+func (m *mockSignalSmoother) Smooth(ctx context.Context, deviceID entities.DeviceID, beaconID string, rssi int32, detectedAt time.Time) (entities.Signal, error) {
+	key := deviceID.String() + ":" + beaconID
+	m.readings[key] = append(m.readings[key], rssi)
+	return entities.NewSignalFromReadings(m.readings[key])
+}
+
+func TestIdentifyCustomerSignalSmoother(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = "cust123"
+
+	// Earlier readings of the pair put the device right next to the beacon
+	smoother := &mockSignalSmoother{readings: map[string][]int32{
+		"app-install-1:550e8400-e29b-41d4-a716-446655440000": {-55, -57},
+	}}
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithSignalSmoother(smoother))
+	assert.NoError(t, err)
+
+	// A single weak reading is averaged out instead of rejecting the identification
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 0, 0, -80)
+	assert.NoError(t, err)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, float32(1.0), result.Identity.GetConfidence(), "Confidence is estimated from the smoothed RSSI of -64 dBm")
+	assert.Len(t, smoother.readings["app-install-1:550e8400-e29b-41d4-a716-446655440000"], 3, "The reading is added to the window")
+
+	_, err = services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithSignalSmoother(nil))
+	assert.Error(t, err, "Smoother is required")
+}
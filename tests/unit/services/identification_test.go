@@ -1,19 +1,26 @@
 package services_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
 	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
 	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
 )
 
 type mockCustomerRepo struct {
 	customers map[string]*entities.Customer
 }
 
-func (r *mockCustomerRepo) FindByID(customerID string) (*entities.Customer, error) {
+func (r *mockCustomerRepo) FindByID(ctx context.Context, customerID string) (*entities.Customer, error) {
 	cust, exists := r.customers[customerID]
 	if !exists {
 		return nil, nil
@@ -21,7 +28,7 @@ func (r *mockCustomerRepo) FindByID(customerID string) (*entities.Customer, erro
 	return cust, nil
 }
 
-func (r *mockCustomerRepo) Save(customer *entities.Customer) error {
+func (r *mockCustomerRepo) Save(ctx context.Context, customer *entities.Customer) error {
 	r.customers[customer.CustomerID] = customer
 	return nil
 }
@@ -30,7 +37,7 @@ type mockBeaconRepo struct {
 	beacons map[string]*entities.Beacon
 }
 
-func (r *mockBeaconRepo) FindByUUID(uuid string) (*entities.Beacon, error) {
+func (r *mockBeaconRepo) FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error) {
 	beacon, exists := r.beacons[uuid]
 	if !exists {
 		return nil, nil
@@ -38,6 +45,52 @@ func (r *mockBeaconRepo) FindByUUID(uuid string) (*entities.Beacon, error) {
 	return beacon, nil
 }
 
+type mockDeviceRepo struct {
+	customerRepo *mockCustomerRepo
+	devices      map[string]string
+	raced        string // Customer bound concurrently on the next BindDevice call
+}
+
+func (r *mockDeviceRepo) FindCustomerID(ctx context.Context, deviceID entities.DeviceID) (string, error) {
+	return r.devices[deviceID.String()], nil
+}
+
+func (r *mockDeviceRepo) BindDevice(ctx context.Context, deviceID entities.DeviceID, customer *entities.Customer) (string, error) {
+	if r.raced != "" {
+		r.devices[deviceID.String()] = r.raced
+	}
+	if existing, ok := r.devices[deviceID.String()]; ok {
+		return existing, nil
+	}
+	r.devices[deviceID.String()] = customer.CustomerID
+	return customer.CustomerID, r.customerRepo.Save(ctx, customer)
+}
+
+// mockIdentityCache holds the latest identity of each customer, as the caching decorator
+// of the server does.
+type mockIdentityCache struct {
+	identities map[string]*aggregates.CustomerIdentity
+}
+
+func (c *mockIdentityCache) GetCustomerIdentity(ctx context.Context, customerID string) (*aggregates.CustomerIdentity, error) {
+	return c.identities[customerID], nil
+}
+
+func newIdentityCache() *mockIdentityCache {
+	return &mockIdentityCache{identities: make(map[string]*aggregates.CustomerIdentity)}
+}
+
+func newDeviceRepo(customerRepo *mockCustomerRepo) *mockDeviceRepo {
+	return &mockDeviceRepo{customerRepo: customerRepo, devices: make(map[string]string)}
+}
+
+func mustDeviceID(t *testing.T, value string) entities.DeviceID {
+	t.Helper()
+	deviceID, err := entities.NewDeviceID(value)
+	assert.NoError(t, err)
+	return deviceID
+}
+
 func TestIdentifyCustomer(t *testing.T) {
 	// Setup mock repositories
 	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
@@ -48,42 +101,43 @@ func TestIdentifyCustomer(t *testing.T) {
 				StoreID:  "store100",
 				Major:    100,
 				Minor:    3,
-				Location: "Table 3",
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
 				Status:   entities.StatusActive,
 			},
 		},
 	}
 
 	// Create service
-	svc, err := services.NewIdentificationService(customerRepo, beaconRepo)
+	deviceRepo := newDeviceRepo(customerRepo)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo)
 	assert.NoError(t, err, "Failed to create IdentificationService")
 
 	// Test identification
 	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
 	assert.NoError(t, err, "Failed to create BeaconData")
 
-	// Ensure customer.LastSeen is at least 1 minute ago to avoid duplicate check
-	customerID := services.GenerateCustomerID(beaconData)
+	customerID := "cust123"
 	cust, err := entities.NewCustomer(customerID, nil)
 	assert.NoError(t, err)
 	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute) // 2 minutes ago
-	err = customerRepo.Save(cust)
+	err = customerRepo.Save(context.Background(), cust)
 	assert.NoError(t, err)
+	deviceRepo.devices["app-install-1"] = customerID
 
-	identity, err := svc.IdentifyCustomer(beaconData)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
 	if !assert.NoError(t, err, "Expected no error identifying customer") {
 		t.Logf("IdentifyCustomer failed: %v", err)
 		return
 	}
-	if !assert.NotNil(t, identity, "CustomerIdentity should not be nil") {
+	if !assert.NotNil(t, result, "CustomerIdentity should not be nil") {
 		return
 	}
 
 	// Validate results
-	assert.Equal(t, customerID, identity.GetCustomerID(), "CustomerID mismatch")
-	assert.Equal(t, "Table 3", identity.GetLocation(), "Location mismatch")
-	assert.True(t, identity.GetConfidence() >= 0.8, "Confidence should be >= 0.8")
-	assert.WithinDuration(t, time.Now().UTC(), identity.GetDetectedAt(), time.Second, "DetectedAt mismatch")
+	assert.Equal(t, customerID, result.Identity.GetCustomerID(), "CustomerID mismatch")
+	assert.Equal(t, "Table 3", result.Identity.GetLocation().Name(), "Location mismatch")
+	assert.True(t, result.Identity.GetConfidence() >= 0.8, "Confidence should be >= 0.8")
+	assert.WithinDuration(t, time.Now().UTC(), result.Identity.GetDetectedAt(), time.Second, "DetectedAt mismatch")
 }
 
 func TestIdentifyCustomerInactiveBeacon(t *testing.T) {
@@ -95,20 +149,324 @@ func TestIdentifyCustomerInactiveBeacon(t *testing.T) {
 				StoreID:  "store100",
 				Major:    100,
 				Minor:    3,
-				Location: "Table 3",
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
 				Status:   entities.StatusInactive,
 			},
 		},
 	}
 
-	svc, err := services.NewIdentificationService(customerRepo, beaconRepo)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, newDeviceRepo(customerRepo))
 	assert.NoError(t, err)
 
 	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
 	assert.NoError(t, err)
 
-	_, err = svc.IdentifyCustomer(beaconData)
+	_, err = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
 	assert.Error(t, err, "Expected error for inactive beacon")
 	// Check if error message contains the relevant substring
 	assert.Contains(t, err.Error(), "not active", "Error should indicate inactive beacon")
+	assert.ErrorIs(t, err, domainerr.ErrBeaconInactive, "Expected inactive beacon")
+
+	_, err = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), mustBeaconData(t, "660e8400-e29b-41d4-a716-446655440000"))
+	assert.ErrorIs(t, err, domainerr.ErrBeaconNotFound, "Expected unknown beacon")
+	assert.ErrorIs(t, err, services.ErrCustomerNotIdentified, "Expected a domain rejection")
+}
+
+func mustBeaconData(t *testing.T, uuid string) entities.BeaconData {
+	t.Helper()
+	beaconData, err := entities.NewBeaconData(uuid, 100, 3, -20)
+	assert.NoError(t, err)
+	return beaconData
+}
+
+type mockIdentificationRecorder struct {
+	customerRepo *mockCustomerRepo
+	visitRepo    *mockVisitRepo // Receives the visit updates if set
+	identities   []*aggregates.CustomerIdentity
+	events       []events.CustomerIdentified
+	visitEvents  []events.Event
+}
+
+func (r *mockIdentificationRecorder) RecordIdentification(ctx context.Context, customer *entities.Customer, identity *aggregates.CustomerIdentity, event events.CustomerIdentified, visits ...ports.VisitUpdate) error {
+	r.identities = append(r.identities, identity)
+	r.events = append(r.events, event)
+	for _, update := range visits {
+		r.visitRepo.save(update.Visit)
+		r.visitEvents = append(r.visitEvents, update.Events...)
+	}
+	return r.customerRepo.Save(ctx, customer)
+}
+
+func TestIdentifyCustomerRecordsEvent(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	recorder := &mockIdentificationRecorder{customerRepo: customerRepo}
+	cache := newIdentityCache()
+
+	deviceRepo := newDeviceRepo(customerRepo)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo,
+		services.WithIdentificationRecorder(recorder),
+		services.WithIdentityCache(cache))
+	assert.NoError(t, err)
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
+	assert.NoError(t, err)
+
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = cust.CustomerID
+	deviceID := mustDeviceID(t, "app-install-1")
+
+	result, err := svc.IdentifyCustomer(context.Background(), deviceID, beaconData)
+	if !assert.NoError(t, err, "Expected no error identifying customer") {
+		return
+	}
+	if !assert.Len(t, recorder.events, 1, "Expected one CustomerIdentified event") {
+		return
+	}
+	assert.Equal(t, result.Identity, recorder.identities[0], "Recorded identity mismatch")
+	event := recorder.events[0]
+	assert.Equal(t, result.Identity.GetCustomerID(), event.Data.CustomerID, "CustomerID mismatch")
+	assert.Equal(t, int32(-20), event.Data.Beacon.RSSI, "Beacon RSSI mismatch")
+	assert.WithinDuration(t, time.Now().UTC(), customerRepo.customers[result.Identity.GetCustomerID()].LastSeen, time.Second, "LastSeen should be updated")
+
+	assert.Equal(t, services.OutcomeIdentified, result.Outcome, "Outcome mismatch")
+
+	// Repeated identifications return the earlier identity without recording events
+	cache.identities[cust.CustomerID] = result.Identity
+	repeated, err := svc.IdentifyCustomer(context.Background(), deviceID, beaconData)
+	if !assert.NoError(t, err, "Expected repeated identification to succeed") {
+		return
+	}
+	assert.Equal(t, services.OutcomeAlreadyIdentified, repeated.Outcome, "Outcome mismatch")
+	assert.Same(t, result.Identity, repeated.Identity, "Expected the cached identity")
+	assert.Len(t, recorder.events, 1, "No event expected for a repeated identification")
+
+	// Once the dedupe window has passed, the customer is identified again
+	cache.identities[cust.CustomerID].DetectedAt = time.Now().UTC().Add(-2 * time.Minute)
+	again, err := svc.IdentifyCustomer(context.Background(), deviceID, beaconData)
+	if assert.NoError(t, err) {
+		assert.Equal(t, services.OutcomeIdentified, again.Outcome, "Outcome mismatch")
+	}
+	assert.Len(t, recorder.events, 2, "Expected an event for the new identification")
+}
+
+func TestIdentifyCustomerFirstVisit(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, newDeviceRepo(customerRepo),
+		services.WithIdentityCache(newIdentityCache()))
+	assert.NoError(t, err)
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
+	assert.NoError(t, err)
+
+	// A device seen for the first time belongs to a customer created just now
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if !assert.NoError(t, err, "Expected first sightings to be identified") {
+		return
+	}
+	assert.Equal(t, services.OutcomeIdentified, result.Outcome, "Outcome mismatch")
+	assert.True(t, strings.HasPrefix(result.Identity.GetCustomerID(), "cust-"), "Expected an anonymous customer")
+}
+
+func TestIdentifyCustomerDetectedAt(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = now.Add(-10 * time.Second)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = "cust123"
+
+	// A reading buffered by a gateway is identified at the time it was detected
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
+	assert.NoError(t, err)
+	detectedAt := now.Add(-time.Minute).Truncate(time.Second)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData.WithDetectedAt(detectedAt))
+	if !assert.NoError(t, err, "Expected late readings within the allowed age to be identified") {
+		return
+	}
+	assert.Equal(t, detectedAt, result.Identity.GetDetectedAt(), "DetectedAt should be the reported detection time")
+	assert.Equal(t, now.Add(-10*time.Second), customerRepo.customers["cust123"].LastSeen, "Late readings should not move LastSeen back")
+
+	// Readings older than the allowed age are rejected
+	_, err = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData.WithDetectedAt(now.Add(-time.Hour)))
+	assert.ErrorIs(t, err, services.ErrCustomerNotIdentified, "Expected stale readings to be rejected")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidArgument, "Expected stale readings to be invalid")
+}
+
+type cancelledBeaconRepo struct{}
+
+func (cancelledBeaconRepo) FindByUUID(ctx context.Context, uuid string) (*entities.Beacon, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestIdentifyCustomerCancelled(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	svc, err := services.NewIdentificationService(customerRepo, cancelledBeaconRepo{}, newDeviceRepo(customerRepo))
+	assert.NoError(t, err)
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = svc.IdentifyCustomer(ctx, mustDeviceID(t, "app-install-1"), beaconData)
+	assert.ErrorIs(t, err, context.Canceled, "Cancellation should propagate from storage")
+	assert.ErrorIs(t, err, domainerr.ErrStorage, "Expected a storage failure")
+	assert.NotErrorIs(t, err, services.ErrCustomerNotIdentified, "Cancellation is not a domain rejection")
+	assert.Empty(t, customerRepo.customers, "No customer should be created")
+}
+
+func TestIdentifyCustomerBindsNewDevices(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo)
+	assert.NoError(t, err)
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -20)
+	assert.NoError(t, err)
+
+	// Two people at the same table are two customers
+	_, _ = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	_, _ = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-2"), beaconData)
+	first, second := deviceRepo.devices["app-install-1"], deviceRepo.devices["app-install-2"]
+	assert.True(t, strings.HasPrefix(first, "cust-"), "Expected an anonymous customer for a new device")
+	assert.NotEqual(t, first, second, "Different devices must not share a customer")
+	assert.Len(t, customerRepo.customers, 2, "Expected one customer per device")
+
+	// The same device is identified as the same customer
+	customerRepo.customers[first].LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if assert.NoError(t, err) {
+		assert.Equal(t, first, result.Identity.GetCustomerID(), "CustomerID mismatch")
+	}
+	assert.Len(t, customerRepo.customers, 2, "No customer should be created for a known device")
+}
+
+func TestIdentifyCustomerConcurrentBinding(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "store100",
+				Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable),
+				Status:   entities.StatusActive,
+			},
+		},
+	}
+	winner, err := entities.NewCustomer("cust-winner", nil)
+	assert.NoError(t, err)
+	winner.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), winner))
+
+	deviceRepo := newDeviceRepo(customerRepo)
+	deviceRepo.raced = winner.CustomerID
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo)
+	assert.NoError(t, err)
+
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 0, 0, -20)
+	assert.NoError(t, err)
+
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if assert.NoError(t, err) {
+		assert.Equal(t, winner.CustomerID, result.Identity.GetCustomerID(), "Expected the customer bound first")
+	}
+	assert.Len(t, customerRepo.customers, 1, "The losing customer must not be saved")
+}
+
+func TestIdentifyByQR(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	recorder := &mockIdentificationRecorder{customerRepo: customerRepo}
+	deviceRepo := newDeviceRepo(customerRepo)
+	cache := newIdentityCache()
+	svc, err := services.NewIdentificationService(customerRepo, &mockBeaconRepo{}, deviceRepo,
+		services.WithIdentificationRecorder(recorder),
+		services.WithIdentityCache(cache))
+	assert.NoError(t, err)
+
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = cust.CustomerID
+
+	qr, err := entities.NewQRData("store100", testutil.MustLocation(t, "Table 7", entities.LocationTypeTable), "nonce-0001")
+	assert.NoError(t, err)
+
+	result, err := svc.IdentifyByQR(context.Background(), mustDeviceID(t, "app-install-1"), qr)
+	if !assert.NoError(t, err, "Expected no error identifying customer") {
+		return
+	}
+	assert.Equal(t, "cust123", result.Identity.GetCustomerID(), "CustomerID mismatch")
+	assert.Equal(t, "store100", result.Identity.GetStoreID(), "StoreID mismatch")
+	assert.Equal(t, "Table 7", result.Identity.GetLocation().Name(), "Location mismatch")
+	assert.Equal(t, float32(1.0), result.Identity.GetConfidence(), "QR identifications are certain")
+	if assert.Len(t, recorder.events, 1, "Expected one CustomerIdentified event") {
+		assert.Equal(t, "qr", recorder.events[0].Data.Source, "Source mismatch")
+		assert.Nil(t, recorder.events[0].Data.Beacon, "QR events carry no beacon block")
+	}
+
+	// The dedupe rule applies to QR scans as well
+	cache.identities[cust.CustomerID] = result.Identity
+	repeated, err := svc.IdentifyByQR(context.Background(), mustDeviceID(t, "app-install-1"), qr)
+	if assert.NoError(t, err, "Expected repeated scan to succeed") {
+		assert.Equal(t, services.OutcomeAlreadyIdentified, repeated.Outcome, "Expected repeated scan to be reported as already identified")
+	}
+	assert.Len(t, recorder.events, 1, "No event expected for a repeated scan")
 }
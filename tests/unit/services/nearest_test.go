@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+const (
+	table3 = "550e8400-e29b-41d4-a716-446655440000"
+	table4 = "660e8400-e29b-41d4-a716-446655440000"
+)
+
+func candidate(beacon *entities.Beacon, rssi int32) services.BeaconCandidate {
+	return services.BeaconCandidate{Beacon: beacon, Signal: entities.SingleReading(rssi)}
+}
+
+func TestNearestBeaconResolver(t *testing.T) {
+	_, err := services.NewNearestBeaconResolver(-1)
+	assert.Error(t, err, "Margin must not be negative")
+
+	resolver, err := services.NewNearestBeaconResolver(6)
+	assert.NoError(t, err)
+
+	a := &entities.Beacon{BeaconID: table3, Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable)}
+	b := &entities.Beacon{BeaconID: table4, Location: testutil.MustLocation(t, "Table 4", entities.LocationTypeTable)}
+
+	_, ok := resolver.Resolve(nil, "")
+	assert.False(t, ok, "No candidates, no beacon")
+
+	nearest, _ := resolver.Resolve([]services.BeaconCandidate{candidate(a, -70), candidate(b, -64)}, "")
+	assert.Equal(t, table4, nearest.Beacon.BeaconID, "The strongest signal wins without a current placement")
+
+	nearest, _ = resolver.Resolve([]services.BeaconCandidate{candidate(a, -70), candidate(b, -66)}, table3)
+	assert.Equal(t, table3, nearest.Beacon.BeaconID, "The current beacon is kept within the margin")
+
+	nearest, _ = resolver.Resolve([]services.BeaconCandidate{candidate(a, -70), candidate(b, -62)}, table3)
+	assert.Equal(t, table4, nearest.Beacon.BeaconID, "A beacon stronger by more than the margin wins")
+
+	weak := &entities.Beacon{BeaconID: table4, Location: testutil.MustLocation(t, "Table 4", entities.LocationTypeTable), TxPower: -75}
+	nearest, _ = resolver.Resolve([]services.BeaconCandidate{candidate(a, -70), candidate(weak, -76)}, "")
+	assert.Equal(t, table4, nearest.Beacon.BeaconID, "Signals are compared relative to each beacon's calibration")
+}
+
+type mockPlacements struct {
+	beacons map[string]string
+}
+
+func (m *mockPlacements) FindNearestBeacon(ctx context.Context, deviceID entities.DeviceID) (string, error) {
+	return m.beacons[deviceID.String()], nil
+}
+
+func (m *mockPlacements) SaveNearestBeacon(ctx context.Context, deviceID entities.DeviceID, beaconID string) error {
+	m.beacons[deviceID.String()] = beaconID
+	return nil
+}
+
+func mustScan(t *testing.T, rssi map[string]int32) entities.BeaconScan {
+	t.Helper()
+	var readings []entities.BeaconData
+	for uuid, value := range rssi {
+		reading, err := entities.NewBeaconData(uuid, 100, 0, value)
+		assert.NoError(t, err)
+		readings = append(readings, reading)
+	}
+	scan, err := entities.NewBeaconScan(readings)
+	assert.NoError(t, err)
+	return scan
+}
+
+func TestIdentifyScan(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			table3: {BeaconID: table3, StoreID: "store100", Location: testutil.MustLocation(t, "Table 3", entities.LocationTypeTable), Status: entities.StatusActive},
+			table4: {BeaconID: table4, StoreID: "store100", Location: testutil.MustLocation(t, "Table 4", entities.LocationTypeTable), Status: entities.StatusActive},
+			"770e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "770e8400-e29b-41d4-a716-446655440000", StoreID: "store100", Location: testutil.MustLocation(t, "Bar", entities.LocationTypeCounter), Status: entities.StatusMaintenance,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = "cust123"
+
+	resolver, err := services.NewNearestBeaconResolver(6)
+	assert.NoError(t, err)
+	placements := &mockPlacements{beacons: make(map[string]string)}
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo,
+		services.WithNearestBeaconResolver(resolver, placements))
+	assert.NoError(t, err)
+
+	// Unknown and inactive beacons are ignored, however strong
+	result, err := svc.IdentifyScan(context.Background(), mustDeviceID(t, "app-install-1"), mustScan(t, map[string]int32{
+		table3:                                 -62,
+		table4:                                 -66,
+		"770e8400-e29b-41d4-a716-446655440000": -30,
+		"880e8400-e29b-41d4-a716-446655440000": -30,
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Table 3", result.Identity.GetLocation().Name(), "The strongest known beacon wins")
+	assert.Equal(t, table3, placements.beacons["app-install-1"], "The placement is remembered")
+
+	// A slightly stronger neighbour does not move the customer
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	result, err = svc.IdentifyScan(context.Background(), mustDeviceID(t, "app-install-1"), mustScan(t, map[string]int32{
+		table3: -65,
+		table4: -62,
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Table 3", result.Identity.GetLocation().Name(), "Hysteresis keeps the current table")
+
+	_, err = svc.IdentifyScan(context.Background(), mustDeviceID(t, "app-install-1"), mustScan(t, map[string]int32{
+		"880e8400-e29b-41d4-a716-446655440000": -40,
+	}))
+	assert.ErrorIs(t, err, services.ErrCustomerNotIdentified, "Scans without known active beacons are rejected")
+
+	// A winner too weak to identify the customer does not become the placement
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	_, err = svc.IdentifyScan(context.Background(), mustDeviceID(t, "app-install-1"), mustScan(t, map[string]int32{
+		table4: -99,
+	}))
+	assert.ErrorIs(t, err, domainerr.ErrLowConfidence, "Expected the weak winner to be rejected")
+	assert.Equal(t, table3, placements.beacons["app-install-1"], "Rejected scans leave the placement unchanged")
+}
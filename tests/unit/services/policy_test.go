@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+func TestIdentifyCustomerIdentificationPolicies(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			"550e8400-e29b-41d4-a716-446655440000": {
+				BeaconID: "550e8400-e29b-41d4-a716-446655440000",
+				StoreID:  "bar7",
+				Major:    100,
+				Minor:    3,
+				Location: testutil.MustLocation(t, "Counter", entities.LocationTypeCounter),
+				Status:   entities.StatusMaintenance,
+			},
+		},
+	}
+	deviceRepo := newDeviceRepo(customerRepo)
+	cust, err := entities.NewCustomer("cust123", nil)
+	assert.NoError(t, err)
+	cust.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	assert.NoError(t, customerRepo.Save(context.Background(), cust))
+	deviceRepo.devices["app-install-1"] = "cust123"
+
+	// Linear confidence of -30 dBm is 0.7, below the default minimum of 0.8
+	beaconData, err := entities.NewBeaconData("550e8400-e29b-41d4-a716-446655440000", 100, 3, -30)
+	assert.NoError(t, err)
+
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithConfidenceEstimator(services.LinearEstimator{}))
+	assert.NoError(t, err)
+	_, err = svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	assert.ErrorIs(t, err, services.ErrCustomerNotIdentified, "Default policy should reject maintenance beacons")
+
+	bar := aggregates.DefaultIdentificationPolicy()
+	bar.MinConfidence = 0.6
+	bar.AllowedBeaconStatuses = []entities.BeaconStatus{entities.StatusActive, entities.StatusMaintenance}
+	policies, err := aggregates.NewIdentificationPolicies(aggregates.DefaultIdentificationPolicy(), map[string]aggregates.IdentificationPolicy{"bar7": bar})
+	assert.NoError(t, err)
+
+	svc, err = services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo,
+		services.WithConfidenceEstimator(services.LinearEstimator{}),
+		services.WithIdentificationPolicies(policies))
+	assert.NoError(t, err)
+	result, err := svc.IdentifyCustomer(context.Background(), mustDeviceID(t, "app-install-1"), beaconData)
+	if !assert.NoError(t, err, "Store policy should accept the identification") {
+		return
+	}
+	assert.InDelta(t, 0.7, result.Identity.GetConfidence(), 0.01)
+
+	_, err = services.NewIdentificationService(customerRepo, beaconRepo, deviceRepo, services.WithIdentificationPolicies(nil))
+	assert.Error(t, err, "Policies are required")
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ports "github.com/sukryu/customer-id.git/internal/application/port"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/domainerr"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+type mockPresenceIndex struct {
+	occupants []ports.Occupant
+	err       error
+}
+
+func (p *mockPresenceIndex) UpdatePresence(ctx context.Context, identity *aggregates.CustomerIdentity, timeout time.Duration) error {
+	return nil
+}
+
+func (p *mockPresenceIndex) ListOccupants(ctx context.Context, storeID, location string, now time.Time) ([]ports.Occupant, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	var occupants []ports.Occupant
+	for _, occupant := range p.occupants {
+		if occupant.StoreID == storeID && (location == "" || occupant.Location.Name() == location) {
+			occupants = append(occupants, occupant)
+		}
+	}
+	return occupants, nil
+}
+
+type mockStoreRepo struct {
+	stores map[string]*aggregates.Store
+	err    error
+}
+
+func (r *mockStoreRepo) FindStore(ctx context.Context, storeID string) (*aggregates.Store, error) {
+	return r.stores[storeID], r.err
+}
+
+func (r *mockStoreRepo) SaveStore(ctx context.Context, store *aggregates.Store) error {
+	return nil
+}
+
+func (r *mockStoreRepo) ListBeacons(ctx context.Context, storeID string) ([]*entities.Beacon, error) {
+	return nil, nil
+}
+
+func newPresenceService(t *testing.T, presence *mockPresenceIndex, stores *mockStoreRepo) services.PresenceService {
+	svc, err := services.NewPresenceService(presence, stores)
+	assert.NoError(t, err, "Failed to create presence service")
+	return svc
+}
+
+func TestGetOccupancy(t *testing.T) {
+	store, err := aggregates.NewStore("store100", "Gangnam Branch", "Asia/Seoul")
+	assert.NoError(t, err)
+	assert.NoError(t, store.AddZone(aggregates.Zone{Name: "Hall", Locations: []string{"Table 5", "Table 6"}}))
+	assert.NoError(t, store.AddZone(aggregates.Zone{Name: "Terrace", Floor: 1, Locations: []string{"Table 10"}}))
+	occupant := func(customerID string, location entities.Location) ports.Occupant {
+		return ports.Occupant{CustomerID: customerID, StoreID: "store100", Location: location}
+	}
+	presence := &mockPresenceIndex{occupants: []ports.Occupant{
+		occupant("cust1", testutil.MustLocation(t, "Table 5", entities.LocationTypeTable)),
+		occupant("cust2", testutil.MustLocation(t, "table 6", entities.LocationTypeTable)),
+		occupant("cust3", testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance)),
+		occupant("cust4", entities.Location{}),
+	}}
+	svc := newPresenceService(t, presence, &mockStoreRepo{stores: map[string]*aggregates.Store{"store100": store}})
+
+	occupancy, err := svc.GetOccupancy(context.Background(), "store100")
+	if !assert.NoError(t, err, "Failed to count occupancy") {
+		return
+	}
+	assert.Equal(t, 4, occupancy.Total)
+	assert.Equal(t, []services.ZoneOccupancy{
+		{Zone: "Hall", Count: 2},
+		{Zone: "Terrace", Floor: 1, Count: 0},
+	}, occupancy.Zones, "Zones are counted case-insensitively, empty ones included")
+	assert.Equal(t, 2, occupancy.Unzoned, "Locations outside every zone and unknown locations are unzoned")
+
+	occupants, err := svc.ListOccupants(context.Background(), "store100", "Table 5")
+	assert.NoError(t, err)
+	if assert.Len(t, occupants, 1) {
+		assert.Equal(t, "cust1", occupants[0].CustomerID)
+	}
+}
+
+func TestPresenceServiceErrors(t *testing.T) {
+	store, err := aggregates.NewStore("store100", "Gangnam Branch", "Asia/Seoul")
+	assert.NoError(t, err)
+	presence := &mockPresenceIndex{}
+	stores := &mockStoreRepo{stores: map[string]*aggregates.Store{"store100": store}}
+	svc := newPresenceService(t, presence, stores)
+
+	_, err = svc.GetOccupancy(context.Background(), "")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidArgument, "Expected error for missing store ID")
+	_, err = svc.ListOccupants(context.Background(), "store200", "")
+	assert.ErrorIs(t, err, domainerr.ErrStoreNotFound, "Expected error for unknown store")
+
+	presence.err = fmt.Errorf("redis unavailable")
+	_, err = svc.ListOccupants(context.Background(), "store100", "")
+	assert.ErrorIs(t, err, domainerr.ErrStorage, "Index failures are storage failures")
+	stores.err = fmt.Errorf("postgres unavailable")
+	_, err = svc.GetOccupancy(context.Background(), "store100")
+	assert.ErrorIs(t, err, domainerr.ErrStorage, "Store lookup failures are storage failures")
+
+	_, err = services.NewPresenceService(nil, stores)
+	assert.Error(t, err, "Expected error for missing presence index")
+	_, err = services.NewPresenceService(presence, nil)
+	assert.Error(t, err, "Expected error for missing store repository")
+}
@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+	"github.com/sukryu/customer-id.git/internal/domain/services"
+	"github.com/sukryu/customer-id.git/tests/testutil"
+)
+
+// mockVisitRepo keeps the open visit of each customer and the ended visits in memory.
+type mockVisitRepo struct {
+	open  map[string]*aggregates.Visit
+	ended []*aggregates.Visit
+}
+
+func newVisitRepo() *mockVisitRepo {
+	return &mockVisitRepo{open: make(map[string]*aggregates.Visit)}
+}
+
+func (r *mockVisitRepo) FindOpenVisit(ctx context.Context, customerID string) (*aggregates.Visit, error) {
+	return r.open[customerID], nil
+}
+
+func (r *mockVisitRepo) EndExpiredVisits(ctx context.Context, now time.Time, limit int, end func(visit *aggregates.Visit) (events.Event, error)) (int, error) {
+	return 0, nil
+}
+
+func (r *mockVisitRepo) save(visit *aggregates.Visit) {
+	if visit.IsOpen() {
+		r.open[visit.CustomerID] = visit
+		return
+	}
+	if r.open[visit.CustomerID] == visit {
+		delete(r.open, visit.CustomerID)
+	}
+	r.ended = append(r.ended, visit)
+}
+
+func eventTypes(evts []events.Event) []string {
+	types := make([]string, len(evts))
+	for i, event := range evts {
+		types[i] = event.Header().EventType
+	}
+	return types
+}
+
+func TestIdentifyCustomerTracksVisits(t *testing.T) {
+	const (
+		entrance  = "550e8400-e29b-41d4-a716-446655440000"
+		table5    = "660e8400-e29b-41d4-a716-446655440000"
+		elsewhere = "770e8400-e29b-41d4-a716-446655440000"
+	)
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			entrance:  {BeaconID: entrance, StoreID: "store100", Location: testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance), Status: entities.StatusActive},
+			table5:    {BeaconID: table5, StoreID: "store100", Location: testutil.MustLocation(t, "Table 5", entities.LocationTypeTable), Status: entities.StatusActive},
+			elsewhere: {BeaconID: elsewhere, StoreID: "store200", Location: testutil.MustLocation(t, "Counter", entities.LocationTypeCounter), Status: entities.StatusActive},
+		},
+	}
+	visitRepo := newVisitRepo()
+	recorder := &mockIdentificationRecorder{customerRepo: customerRepo, visitRepo: visitRepo}
+	policy := aggregates.DefaultIdentificationPolicy()
+	policy.VisitTimeout = 2 * time.Minute
+	policy.MaxDetectionAge = 10 * time.Minute
+	policies, err := aggregates.NewIdentificationPolicies(policy, nil)
+	assert.NoError(t, err)
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, newDeviceRepo(customerRepo),
+		services.WithIdentificationRecorder(recorder),
+		services.WithIdentificationPolicies(policies),
+		services.WithVisitTracking(visitRepo),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+	deviceID := mustDeviceID(t, "app-install-1")
+	start := time.Now().UTC().Add(-8 * time.Minute).Truncate(time.Second)
+	identify := func(beaconID string, at time.Duration) []string {
+		t.Helper()
+		before := len(recorder.visitEvents)
+		_, err := svc.IdentifyCustomer(context.Background(), deviceID, mustBeaconData(t, beaconID).WithDetectedAt(start.Add(at)))
+		assert.NoError(t, err)
+		return eventTypes(recorder.visitEvents[before:])
+	}
+
+	assert.Equal(t, []string{events.TypeVisitStarted}, identify(entrance, 0), "The first identification starts a visit")
+	assert.Equal(t, []string{events.TypeLocationChanged}, identify(table5, time.Minute), "Moving to a table changes the location")
+	changed := recorder.visitEvents[len(recorder.visitEvents)-1].(events.LocationChanged)
+	assert.Equal(t, "Entrance", changed.Data.From.Location)
+	assert.Equal(t, int64(60), changed.Data.From.DurationSeconds, "Time spent at the entrance mismatch")
+	assert.Equal(t, "Table 5", changed.Data.To.Location)
+
+	assert.Empty(t, identify(table5, 90*time.Second), "Staying at the table emits nothing")
+	assert.Empty(t, identify(entrance, 70*time.Second), "Late readings leave the visit unchanged")
+	var customerID string
+	for id := range visitRepo.open {
+		customerID = id
+	}
+	visit := visitRepo.open[customerID]
+	if assert.NotNil(t, visit, "Expected an open visit") {
+		assert.Equal(t, start.Add(90*time.Second), visit.LastSeenAt)
+		assert.Len(t, visit.Stays, 2)
+	}
+
+	// Returning after the timeout ends the visit where the customer was last seen
+	assert.Equal(t, []string{events.TypeVisitEnded, events.TypeVisitStarted}, identify(table5, 5*time.Minute))
+	if assert.Len(t, visitRepo.ended, 1) {
+		assert.Equal(t, start.Add(90*time.Second), visitRepo.ended[0].EndedAt, "Visits end when the customer was last seen")
+	}
+	ended := recorder.visitEvents[len(recorder.visitEvents)-2].(events.VisitEnded)
+	assert.Equal(t, int64(90), ended.Data.DurationSeconds, "Visit duration mismatch")
+	if assert.Len(t, ended.Data.Stays, 2) {
+		assert.Equal(t, "Table 5", ended.Data.Stays[1].Location)
+		assert.Equal(t, int64(30), ended.Data.Stays[1].DurationSeconds, "Time spent at the table mismatch")
+	}
+
+	// Being identified at another store ends the visit at once
+	assert.Equal(t, []string{events.TypeVisitEnded, events.TypeVisitStarted}, identify(elsewhere, 5*time.Minute+30*time.Second))
+	assert.Equal(t, "store200", visitRepo.open[customerID].StoreID)
+	assert.Len(t, visitRepo.ended, 2)
+}
+
+func TestIdentifyCustomerMovesWithinDedupeWindow(t *testing.T) {
+	const (
+		entrance = "550e8400-e29b-41d4-a716-446655440000"
+		table5   = "660e8400-e29b-41d4-a716-446655440000"
+	)
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	beaconRepo := &mockBeaconRepo{
+		beacons: map[string]*entities.Beacon{
+			entrance: {BeaconID: entrance, StoreID: "store100", Location: testutil.MustLocation(t, "Entrance", entities.LocationTypeEntrance), Status: entities.StatusActive},
+			table5:   {BeaconID: table5, StoreID: "store100", Location: testutil.MustLocation(t, "Table 5", entities.LocationTypeTable), Status: entities.StatusActive},
+		},
+	}
+	visitRepo := newVisitRepo()
+	recorder := &mockIdentificationRecorder{customerRepo: customerRepo, visitRepo: visitRepo}
+	identities := newIdentityCache()
+	svc, err := services.NewIdentificationService(customerRepo, beaconRepo, newDeviceRepo(customerRepo),
+		services.WithIdentificationRecorder(recorder),
+		services.WithIdentityCache(identities),
+		services.WithVisitTracking(visitRepo),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+	deviceID := mustDeviceID(t, "app-install-1")
+	start := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	identify := func(beaconID string, at time.Duration) (services.Outcome, []string) {
+		t.Helper()
+		before := len(recorder.visitEvents)
+		result, err := svc.IdentifyCustomer(context.Background(), deviceID, mustBeaconData(t, beaconID).WithDetectedAt(start.Add(at)))
+		if !assert.NoError(t, err) {
+			return "", nil
+		}
+		identities.identities[result.Identity.CustomerID] = result.Identity
+		return result.Outcome, eventTypes(recorder.visitEvents[before:])
+	}
+
+	outcome, _ := identify(entrance, 0)
+	assert.Equal(t, services.OutcomeIdentified, outcome)
+	outcome, types := identify(entrance, 10*time.Second)
+	assert.Equal(t, services.OutcomeAlreadyIdentified, outcome, "Staying put within the dedupe window is a duplicate")
+	assert.Empty(t, types)
+
+	outcome, types = identify(table5, 30*time.Second)
+	assert.Equal(t, services.OutcomeIdentified, outcome, "Moving within the dedupe window is not a duplicate")
+	assert.Equal(t, []string{events.TypeLocationChanged}, types)
+	changed := recorder.visitEvents[len(recorder.visitEvents)-1].(events.LocationChanged)
+	assert.Equal(t, "Entrance", changed.Data.From.Location)
+	assert.Equal(t, int64(30), changed.Data.From.DurationSeconds, "The entrance stay ends when the customer moved")
+
+	outcome, _ = identify(entrance, 20*time.Second)
+	assert.Equal(t, services.OutcomeAlreadyIdentified, outcome, "Late readings are duplicates wherever they were taken")
+}
+
+func TestWithVisitTrackingRequiresRecorder(t *testing.T) {
+	customerRepo := &mockCustomerRepo{customers: make(map[string]*entities.Customer)}
+	_, err := services.NewIdentificationService(customerRepo, &mockBeaconRepo{}, newDeviceRepo(customerRepo),
+		services.WithVisitTracking(newVisitRepo()))
+	assert.Error(t, err, "Visits are saved by the recorder")
+
+	_, err = services.NewIdentificationService(customerRepo, &mockBeaconRepo{}, newDeviceRepo(customerRepo),
+		services.WithVisitTracking(nil))
+	assert.Error(t, err, "Expected error for a missing visit repository")
+}
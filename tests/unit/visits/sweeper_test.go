@@ -0,0 +1,123 @@
+package visits_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/customer-id.git/internal/domain/aggregates"
+	"github.com/sukryu/customer-id.git/internal/domain/entities"
+	"github.com/sukryu/customer-id.git/internal/domain/events"
+	"github.com/sukryu/customer-id.git/internal/infrastructure/visits"
+	"go.uber.org/zap/zaptest"
+)
+
+// mockVisitRepo keeps open visits in memory and mimics the sweep contract of the
+// Postgres repository: each batch is ended as a whole or not at all.
+type mockVisitRepo struct {
+	open    []*aggregates.Visit
+	events  []events.Event
+	batches int
+	failAt  int // Batch that fails, counting from 1; 0 never fails
+}
+
+func (r *mockVisitRepo) FindOpenVisit(ctx context.Context, customerID string) (*aggregates.Visit, error) {
+	for _, visit := range r.open {
+		if visit.CustomerID == customerID {
+			return visit, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockVisitRepo) EndExpiredVisits(ctx context.Context, now time.Time, limit int, end func(visit *aggregates.Visit) (events.Event, error)) (int, error) {
+	r.batches++
+	if r.batches == r.failAt {
+		return 0, fmt.Errorf("database unavailable")
+	}
+	var remaining []*aggregates.Visit
+	ended := 0
+	for _, visit := range r.open {
+		if ended == limit || !visit.IsExpiredAt(now) {
+			remaining = append(remaining, visit)
+			continue
+		}
+		event, err := end(visit)
+		if err != nil {
+			return 0, err
+		}
+		r.events = append(r.events, event)
+		ended++
+	}
+	r.open = remaining
+	return ended, nil
+}
+
+func openVisits(t *testing.T, start time.Time, customerIDs ...string) []*aggregates.Visit {
+	location, err := entities.NewLocation("Table 5", entities.LocationTypeTable)
+	assert.NoError(t, err)
+	visits := make([]*aggregates.Visit, len(customerIDs))
+	for i, customerID := range customerIDs {
+		visit, err := aggregates.StartVisit(&aggregates.CustomerIdentity{
+			CustomerID: customerID,
+			StoreID:    "store100",
+			Location:   location,
+			Confidence: 0.95,
+			DetectedAt: start,
+		}, 30*time.Minute)
+		assert.NoError(t, err)
+		visits[i] = visit
+	}
+	return visits
+}
+
+func TestSweeperSweep(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	repo := &mockVisitRepo{open: openVisits(t, start, "cust1", "cust2", "cust3")}
+	recent := openVisits(t, start.Add(20*time.Minute), "cust4")
+	repo.open = append(repo.open, recent...)
+
+	sweeper, err := visits.NewSweeper(repo, time.Second, 2, zaptest.NewLogger(t))
+	assert.NoError(t, err, "Failed to create sweeper")
+
+	ended := sweeper.Sweep(context.Background(), start.Add(45*time.Minute))
+	assert.Equal(t, 3, ended, "Expected all expired visits to end across batches")
+	assert.Equal(t, 2, repo.batches, "Expected sweeping to stop after a partial batch")
+	assert.Equal(t, recent, repo.open, "Visits within the timeout stay open")
+	for _, event := range repo.events {
+		if assert.IsType(t, events.VisitEnded{}, event) {
+			assert.Equal(t, start, event.(events.VisitEnded).Data.EndedAt, "Visits end when the customer was last seen")
+		}
+	}
+}
+
+func TestSweeperSweepStopsAtFailure(t *testing.T) {
+	start := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	repo := &mockVisitRepo{open: openVisits(t, start, "cust1", "cust2", "cust3"), failAt: 2}
+
+	sweeper, err := visits.NewSweeper(repo, time.Second, 1, zaptest.NewLogger(t))
+	assert.NoError(t, err)
+
+	ended := sweeper.Sweep(context.Background(), start.Add(time.Hour))
+	assert.Equal(t, 1, ended, "Expected sweeping to stop at the failed batch")
+	assert.Len(t, repo.open, 2, "Visits of the failed batch must stay open")
+
+	// The remaining visits are ended on the next poll
+	ended = sweeper.Sweep(context.Background(), start.Add(time.Hour))
+	assert.Equal(t, 2, ended)
+	assert.Empty(t, repo.open)
+}
+
+func TestNewSweeperValidation(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	_, err := visits.NewSweeper(nil, time.Second, 10, logger)
+	assert.Error(t, err, "Expected error for missing repository")
+	_, err = visits.NewSweeper(&mockVisitRepo{}, 0, 10, logger)
+	assert.Error(t, err, "Expected error for non-positive interval")
+	_, err = visits.NewSweeper(&mockVisitRepo{}, time.Second, 0, logger)
+	assert.Error(t, err, "Expected error for non-positive batch size")
+	_, err = visits.NewSweeper(&mockVisitRepo{}, time.Second, 10, nil)
+	assert.Error(t, err, "Expected error for missing logger")
+}